@@ -0,0 +1,75 @@
+package chrono
+
+import (
+    "sync"
+    "time"
+)
+
+// BucketStat 是 Bucketer 中单个时间桶的聚合统计结果。
+type BucketStat struct {
+    Count int     // 落入该时间桶的样本数量
+    Sum   float64 // 样本值之和
+    Min   float64 // 样本值的最小值
+    Max   float64 // 样本值的最大值
+}
+
+// NewBucketer 创建一个按照给定时间单位划分时间桶的聚合器。
+//
+// 关键行为说明：
+//  - 当 unit 为零时，默认使用一天作为时间单位
+//  - 对于定义外的单位，函数会抛出异常，与 StartOf 的行为一致
+//
+// 使用建议：
+// 适用于将带时间戳的指标样本按固定宽度（如每分钟、每天）进行汇总的场景。
+func NewBucketer(unit Unit) *Bucketer {
+    if _, err := StartOfE(time.Time{}, unit); err != nil {
+        panic(err)
+    }
+    return &Bucketer{
+        unit:    unit,
+        buckets: make(map[time.Time]BucketStat),
+    }
+}
+
+// Bucketer 是一个基于 StartOf 的时间桶聚合器，通过 NewBucketer 创建。
+//
+// 每个样本将被归入以 StartOf(t, unit) 为键的时间桶中，并累计数量、总和、最小值及最大值。
+//
+// 关键行为说明：
+//  - 时间桶的键由 StartOf 计算得出，不携带单调时钟读数，可直接作为 map 键比较
+//  - 时间桶的键保留样本的时区，相同时刻但时区不同的样本将落入不同的时间桶，如需合并请在添加前统一时区
+//  - 所有方法都是并发安全的
+type Bucketer struct {
+    mu      sync.Mutex
+    unit    Unit                     // 时间桶的宽度
+    buckets map[time.Time]BucketStat // 以时间桶起始点为键的统计结果
+}
+
+// Add 将时间为 t、值为 v 的样本添加到其所属的时间桶中。
+func (b *Bucketer) Add(t time.Time, v float64) {
+    key := StartOf(t, b.unit)
+
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    stat, exist := b.buckets[key]
+    if !exist || v < stat.Min {
+        stat.Min = v
+    }
+    if !exist || v > stat.Max {
+        stat.Max = v
+    }
+    stat.Count++
+    stat.Sum += v
+    b.buckets[key] = stat
+}
+
+// Buckets 返回所有时间桶统计结果的副本，键为时间桶的起始点。
+func (b *Bucketer) Buckets() map[time.Time]BucketStat {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    buckets := make(map[time.Time]BucketStat, len(b.buckets))
+    for key, stat := range b.buckets {
+        buckets[key] = stat
+    }
+    return buckets
+}
@@ -0,0 +1,41 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestBucketer(t *testing.T) {
+    bucketer := chrono.NewBucketer(chrono.UnitMinute)
+    base := time.Now().Truncate(time.Minute)
+    bucketer.Add(base.Add(10*time.Second), 3)
+    bucketer.Add(base.Add(20*time.Second), -1)
+    bucketer.Add(base.Add(59*time.Second), 5)
+    bucketer.Add(base.Add(time.Minute), 7)
+
+    buckets := bucketer.Buckets()
+    if len(buckets) != 2 {
+        t.Fatalf("Buckets() = %v, want 2 buckets", buckets)
+    }
+
+    // 使用不携带单调时钟的键进行查找
+    key := time.Unix(0, base.UnixNano()).In(base.Location())
+    want := chrono.BucketStat{Count: 3, Sum: 7, Min: -1, Max: 5}
+    if got, exist := buckets[key]; !exist || got != want {
+        t.Errorf("Buckets()[%v] = %+v, want %+v", key, got, want)
+    }
+    want = chrono.BucketStat{Count: 1, Sum: 7, Min: 7, Max: 7}
+    if got := buckets[key.Add(time.Minute)]; got != want {
+        t.Errorf("Buckets()[%v] = %+v, want %+v", key.Add(time.Minute), got, want)
+    }
+}
+
+func TestNewBucketerUnsupportedUnit(t *testing.T) {
+    defer func() {
+        if recover() == nil {
+            t.Error("NewBucketer(12345) did not panic")
+        }
+    }()
+    chrono.NewBucketer(12345)
+}
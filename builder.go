@@ -0,0 +1,48 @@
+package chrono
+
+import "time"
+
+// Of 创建一个以 t 为初始值的 TimeBuilder，用于以链式调用的方式构造时间。
+//
+// 例如 "今天 9 点" 可以表示为 Of(now).StartOf(UnitDay).Add(9 * time.Hour).Time()。
+func Of(t time.Time) TimeBuilder {
+    return TimeBuilder{t: t}
+}
+
+// TimeBuilder 是用于链式构造时间的不可变包装，通过 Of 创建。
+//
+// 每个方法都将返回一个新的 TimeBuilder，原有的 TimeBuilder 保持不变，因此可以安全地复用中间结果，例如基于同一个 "今天零点" 派生出多个时刻。
+// 各方法的行为与对应的函数或 time.Time 方法完全一致，TimeBuilder 仅提供了更易读的书写方式。
+type TimeBuilder struct {
+    t time.Time
+}
+
+// StartOf 返回以 StartOf(t, unit) 作为值的新 TimeBuilder，对于定义外的单位将抛出异常
+func (b TimeBuilder) StartOf(unit Unit) TimeBuilder {
+    return TimeBuilder{t: StartOf(b.t, unit)}
+}
+
+// EndOf 返回以 EndOf(t, unit) 作为值的新 TimeBuilder，对于定义外的单位将抛出异常
+func (b TimeBuilder) EndOf(unit Unit) TimeBuilder {
+    return TimeBuilder{t: EndOf(b.t, unit)}
+}
+
+// Add 返回以 t.Add(d) 作为值的新 TimeBuilder
+func (b TimeBuilder) Add(d time.Duration) TimeBuilder {
+    return TimeBuilder{t: b.t.Add(d)}
+}
+
+// AddDate 返回以 t.AddDate(years, months, days) 作为值的新 TimeBuilder
+func (b TimeBuilder) AddDate(years, months, days int) TimeBuilder {
+    return TimeBuilder{t: b.t.AddDate(years, months, days)}
+}
+
+// In 返回以 t.In(loc) 作为值的新 TimeBuilder，后续的 StartOf、EndOf 等方法将基于 loc 时区进行计算
+func (b TimeBuilder) In(loc *time.Location) TimeBuilder {
+    return TimeBuilder{t: b.t.In(loc)}
+}
+
+// Time 返回构造完成的时间
+func (b TimeBuilder) Time() time.Time {
+    return b.t
+}
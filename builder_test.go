@@ -0,0 +1,41 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestTimeBuilder(t *testing.T) {
+    now := time.Date(2023, 10, 18, 15, 30, 0, 0, time.UTC)
+    today := chrono.Of(now).StartOf(chrono.UnitDay)
+
+    var tests = []struct {
+        name     string
+        result   time.Time
+        expected time.Time
+    }{
+        {"Today 9am", today.Add(9 * time.Hour).Time(), time.Date(2023, 10, 18, 9, 0, 0, 0, time.UTC)},
+        {"Tomorrow 9am", today.AddDate(0, 0, 1).Add(9 * time.Hour).Time(), time.Date(2023, 10, 19, 9, 0, 0, 0, time.UTC)},
+        {"End of month", chrono.Of(now).EndOf(chrono.UnitMonth).Time(), chrono.EndOf(now, chrono.UnitMonth)},
+        {"Today unchanged", today.Time(), time.Date(2023, 10, 18, 0, 0, 0, 0, time.UTC)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if !tt.result.Equal(tt.expected) {
+                t.Errorf("%s = %v, want %v", tt.name, tt.result, tt.expected)
+            }
+        })
+    }
+
+    location, err := time.LoadLocation("Asia/Shanghai")
+    if err != nil {
+        t.Skip(err)
+    }
+    // 2023-10-18 15:30 UTC 在上海时区已是 2023-10-18 23:30，下一小时即进入次日
+    result := chrono.Of(now).In(location).Add(time.Hour).StartOf(chrono.UnitDay).Time()
+    if expected := time.Date(2023, 10, 19, 0, 0, 0, 0, location); !result.Equal(expected) || result.Location() != location {
+        t.Errorf("In().StartOf() = %v, want %v", result, expected)
+    }
+}
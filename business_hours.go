@@ -0,0 +1,71 @@
+package chrono
+
+import (
+    "fmt"
+    "time"
+)
+
+// NewBusinessHours 创建一个在 weekdays 指定的工作日中，从 open 开始至 close 结束的每日营业时间。
+//
+// 参数 open 与 close 均为距离午夜的偏移量，例如 9*time.Hour 表示 09:00，当 open 晚于 close 时（例如 22:00 至 06:00），
+// 营业时间将被视为跨越午夜，即从工作日的 open 开始，至次日的 close 结束。
+//
+// 关键行为说明：
+//  - open 与 close 必须位于 [0, 24h) 范围内，否则函数会抛出包装了 ErrOffsetOutOfRange 的异常
+//  - 当 open 等于 close 时，营业时间将被视为从工作日的 open 开始持续 24 小时
+//  - 当 weekdays 为空时，任何时间都不在营业时间内
+func NewBusinessHours(open, close time.Duration, weekdays ...time.Weekday) BusinessHours {
+    for _, offset := range []time.Duration{open, close} {
+        if offset < 0 || offset >= 24*time.Hour {
+            panic(fmt.Errorf("%w: %v", ErrOffsetOutOfRange, offset))
+        }
+    }
+    hours := BusinessHours{open: open, close: close}
+    for _, weekday := range weekdays {
+        hours.weekdays[weekday%7] = true
+    }
+    return hours
+}
+
+// BusinessHours 表示每周固定工作日中的每日营业时间，通过 NewBusinessHours 创建。
+//
+// 营业时间基于传入时间所在的时区，以墙上时间进行判断，适用于访问控制、客服排班等场景。
+//
+// 关键行为说明：
+//  - 营业时间为左闭右开区间，即 open 时刻视为营业，close 时刻视为已结束营业
+//  - 跨越午夜的营业时间归属于其开始的那一天，例如周五 22:00 至周六 06:00 仅要求周五为工作日
+type BusinessHours struct {
+    open     time.Duration // 距离午夜的开始营业偏移量
+    close    time.Duration // 距离午夜的结束营业偏移量
+    weekdays [7]bool       // 以 time.Weekday 为下标的工作日集合
+}
+
+// Contains 判断时间 t 是否位于营业时间内。
+func (h BusinessHours) Contains(t time.Time) bool {
+    open, close := momentOf(t, h.open), momentOf(t, h.close)
+    if h.open < h.close {
+        return h.weekdays[t.Weekday()] && !t.Before(open) && t.Before(close)
+    }
+
+    // 跨越午夜的营业时间，既可能是今天开始的营业，也可能是昨天开始、今天结束的营业
+    yesterday := time.Date(t.Year(), t.Month(), t.Day()-1, 12, 0, 0, 0, t.Location()).Weekday()
+    return (h.weekdays[t.Weekday()] && !t.Before(open)) || (h.weekdays[yesterday] && t.Before(close))
+}
+
+// NextOpen 返回不早于 t 的下一次开始营业的时间，当 t 位于营业时间内时直接返回 t。
+//
+// 关键行为说明：
+//  - 当不存在任何工作日时返回零值时间
+//  - 开始营业的时刻因夏令时切换而不存在时，将按照 time.Date 的规则进行调整
+func (h BusinessHours) NextOpen(t time.Time) time.Time {
+    if h.Contains(t) {
+        return t
+    }
+    for i := 0; i <= 7; i++ {
+        day := time.Date(t.Year(), t.Month(), t.Day()+i, 12, 0, 0, 0, t.Location())
+        if open := momentOf(day, h.open); h.weekdays[day.Weekday()] && !open.Before(t) {
+            return open
+        }
+    }
+    return time.Time{}
+}
@@ -0,0 +1,49 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestBusinessHours(t *testing.T) {
+    weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+    daytime := chrono.NewBusinessHours(9*time.Hour, 18*time.Hour, weekdays...)
+    overnight := chrono.NewBusinessHours(22*time.Hour, 6*time.Hour, weekdays...)
+
+    // 2023-10-06 为周五
+    at := func(day, hour, min int) time.Time {
+        return time.Date(2023, 10, day, hour, min, 0, 0, time.UTC)
+    }
+    tests := []struct {
+        name     string
+        hours    chrono.BusinessHours
+        t        time.Time
+        contains bool
+        nextOpen time.Time
+    }{
+        {name: "Daytime open", hours: daytime, t: at(6, 9, 0), contains: true, nextOpen: at(6, 9, 0)},
+        {name: "Daytime closed", hours: daytime, t: at(6, 18, 0), contains: false, nextOpen: at(9, 9, 0)},
+        {name: "Daytime weekend", hours: daytime, t: at(7, 12, 0), contains: false, nextOpen: at(9, 9, 0)},
+        {name: "Daytime before open", hours: daytime, t: at(5, 8, 59), contains: false, nextOpen: at(5, 9, 0)},
+        {name: "Overnight evening", hours: overnight, t: at(6, 23, 0), contains: true, nextOpen: at(6, 23, 0)},
+        {name: "Overnight after midnight", hours: overnight, t: at(7, 5, 59), contains: true, nextOpen: at(7, 5, 59)},
+        {name: "Overnight closed", hours: overnight, t: at(7, 6, 0), contains: false, nextOpen: at(9, 22, 0)},
+        {name: "Overnight from weekend", hours: overnight, t: at(9, 3, 0), contains: false, nextOpen: at(9, 22, 0)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if contains := tt.hours.Contains(tt.t); contains != tt.contains {
+                t.Errorf("Contains(%v) = %v, want %v", tt.t, contains, tt.contains)
+            }
+            if next := tt.hours.NextOpen(tt.t); !next.Equal(tt.nextOpen) {
+                t.Errorf("NextOpen(%v) = %v, want %v", tt.t, next, tt.nextOpen)
+            }
+        })
+    }
+
+    if next := chrono.NewBusinessHours(9*time.Hour, 18*time.Hour).NextOpen(at(6, 12, 0)); !next.IsZero() {
+        t.Errorf("NextOpen() without weekdays = %v, want zero", next)
+    }
+}
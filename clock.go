@@ -0,0 +1,69 @@
+package chrono
+
+import (
+    "sync"
+    "time"
+)
+
+var (
+    _ Clock = SystemClock{}
+    _ Clock = (*FakeClock)(nil)
+)
+
+// Clock 定义了可替换的当前时间来源。
+//
+// 包内的时刻计算函数均通过参数接收 now，而应用代码可以依赖 Clock 获取 now，并在测试中替换为 FakeClock，
+// 从而避免直接调用 time.Now() 导致的不确定性。timing.Clock 同样以 Clock 为基础，因此二者可以共享同一套测试替身。
+//
+// 关键行为说明：
+//  - 实现必须是并发安全的
+type Clock interface {
+    // Now 返回当前时间
+    Now() time.Time
+}
+
+// SystemClock 是基于系统时钟的 Clock 实现，其零值即可直接使用。
+type SystemClock struct{}
+
+// Now 返回 time.Now() 的结果
+func (SystemClock) Now() time.Time {
+    return time.Now()
+}
+
+// NewFakeClock 创建一个以 start 作为初始时间的伪造时钟。
+//
+// 伪造时钟的时间仅在调用 Advance 或 Set 时发生变化，适用于确定性测试等场景。
+func NewFakeClock(start time.Time) *FakeClock {
+    return &FakeClock{now: start}
+}
+
+// FakeClock 是一个手动控制的 Clock 实现，通过 NewFakeClock 创建。
+//
+// 关键行为说明：
+//  - 所有方法都是并发安全的
+//  - 如需驱动时间轮，请使用同时实现了定时等待的 timing.ManualClock
+type FakeClock struct {
+    mu  sync.Mutex
+    now time.Time
+}
+
+// Now 返回时钟当前的时间
+func (c *FakeClock) Now() time.Time {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.now
+}
+
+// Set 将时钟设置为 t
+func (c *FakeClock) Set(t time.Time) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.now = t
+}
+
+// Advance 将时钟向后推进 d，当 d 为负数时时钟将向前回拨
+func (c *FakeClock) Advance(d time.Duration) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.now = c.now.Add(d)
+}
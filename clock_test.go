@@ -0,0 +1,36 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestSystemClock(t *testing.T) {
+    before := time.Now()
+    now := chrono.SystemClock{}.Now()
+    if now.Before(before) || now.After(time.Now()) {
+        t.Errorf("Now() = %v, want between %v and now", now, before)
+    }
+}
+
+func TestFakeClock(t *testing.T) {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    var clock chrono.Clock = chrono.NewFakeClock(start)
+    fake := clock.(*chrono.FakeClock)
+
+    if now := clock.Now(); !now.Equal(start) {
+        t.Fatalf("Now() = %v, want %v", now, start)
+    }
+
+    fake.Advance(90 * time.Minute)
+    if now, expected := clock.Now(), start.Add(90*time.Minute); !now.Equal(expected) {
+        t.Errorf("Now() after Advance = %v, want %v", now, expected)
+    }
+
+    target := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+    fake.Set(target)
+    if now := clock.Now(); !now.Equal(target) {
+        t.Errorf("Now() after Set = %v, want %v", now, target)
+    }
+}
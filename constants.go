@@ -5,16 +5,22 @@ import "time"
 // Unit 定义了时间单位，用于表示时间间隔或持续时间。
 //
 // 该类型通常与时间相关的操作一起使用，例如定时任务的调度、延迟执行等。支持的时间单位包括秒、毫秒等。
+//
+// 关键行为说明：
+//  - 纳秒至周的单位值等同于其对应的 time.Duration，可直接参与时长计算
+//  - 星期、月、年等日历单位使用负数哨兵值定义，不能被视为时长参与计算，可通过 IsCalendarUnit 进行判断
 type Unit int
 
 const (
-    UnitSunday      Unit = 0                 // UnitSunday 表示星期天，用于定义以星期为基准的时间间隔或持续时间。
-    UnitMonday      Unit = 10                // UnitMonday 表示星期一，用于定义以星期为基准的时间间隔或持续时间。
-    UnitTuesday     Unit = 20                // UnitTuesday 表示星期二，用于定义以星期为基准的时间间隔或持续时间。
-    UnitWednesday   Unit = 30                // UnitWednesday 表示星期三，用于定义以星期为基准的时间间隔或持续时间。
-    UnitThursday    Unit = 40                // UnitThursday 表示星期四，用于定义以星期为基准的时间间隔或持续时间。
-    UnitFriday      Unit = 50                // UnitFriday 表示星期五，用于定义以星期为基准的时间间隔或持续时间。
-    UnitSaturday    Unit = 60                // UnitSaturday 表示星期六，用于定义以星期为基准的时间间隔或持续时间。
+    UnitSunday      Unit = -1                // UnitSunday 表示星期天，用于定义以星期为基准的时间间隔或持续时间。
+    UnitMonday      Unit = -2                // UnitMonday 表示星期一，用于定义以星期为基准的时间间隔或持续时间。
+    UnitTuesday     Unit = -3                // UnitTuesday 表示星期二，用于定义以星期为基准的时间间隔或持续时间。
+    UnitWednesday   Unit = -4                // UnitWednesday 表示星期三，用于定义以星期为基准的时间间隔或持续时间。
+    UnitThursday    Unit = -5                // UnitThursday 表示星期四，用于定义以星期为基准的时间间隔或持续时间。
+    UnitFriday      Unit = -6                // UnitFriday 表示星期五，用于定义以星期为基准的时间间隔或持续时间。
+    UnitSaturday    Unit = -7                // UnitSaturday 表示星期六，用于定义以星期为基准的时间间隔或持续时间。
+    UnitMonth       Unit = -8                // UnitMonth 表示月时间单位，用于定义以月份为基准的时间间隔或持续时间。
+    UnitYear        Unit = -9                // UnitYear 表示年时间单位，用于定义长时间间隔或持续时间。
     UnitNanosecond       = Unit(Nanosecond)  // UnitNanosecond 定义了纳秒时间单位，适用于需要高精度时间控制的场景。
     UnitMicrosecond      = Unit(Microsecond) // UnitMicrosecond 定义了微秒时间单位，适用于需要较高精度时间控制的场景。
     UnitMillisecond      = Unit(Millisecond) // UnitMillisecond 定义了毫秒时间单位，适用于需要中等精度时间控制的场景。
@@ -23,11 +29,78 @@ const (
     UnitHour             = Unit(Hour)        // UnitHour 定义了小时时间单位，适用于需要以小时为精度的时间控制场景。
     UnitDay              = Unit(Day)         // UnitDay 定义了天时间单位，适用于需要以天为精度的时间控制场景。
     UnitWeek             = Unit(Week)        // UnitWeek 定义了周时间单位，适用于需要以周为精度的时间控制场景。
-    UnitMonth            = Unit(Week * 30)   // UnitMonth 表示月时间单位，用于定义以月份为基准的时间间隔或持续时间。
-    UnitYear             = UnitMonth * 12    // UnitYear 表示年时间单位，用于定义长时间间隔或持续时间。
-
 )
 
+// IsCalendarUnit 判断给定的时间单位是否为日历单位。
+//
+// 日历单位包括星期（UnitSunday 至 UnitSaturday）、月（UnitMonth）和年（UnitYear），
+// 它们的长度随日历变化，使用哨兵值定义，不对应任何固定的 time.Duration。
+//
+// 关键行为说明：
+//  - UnitDay、UnitWeek 等单位对应固定的时长，因此返回 false
+//
+// 使用建议：
+// 在将 Unit 转换为 time.Duration 参与计算前，应先通过该函数排除日历单位。
+func IsCalendarUnit(unit Unit) bool {
+    return unit >= UnitYear && unit <= UnitSunday
+}
+
+// UnitFromDuration 将时长安全地转换为对应的时间单位，仅当 d 恰好为 UnitNanosecond 至 UnitWeek 中的某个单位时第二个返回值为 true。
+//
+// 由于时长单位直接以 time.Duration 的值定义，而日历单位使用哨兵值定义，通过 Unit(d) 直接转换任意时长可能得到一个与哨兵值相同、
+// 或不被任何函数识别的单位，例如 Unit(-time.Nanosecond) 即为 UnitSunday。该函数拒绝所有非预定义的时长，以避免此类混淆。
+//
+// 关键行为说明：
+//  - 10 * time.Nanosecond 等非预定义的时长将返回 false，而不会被视为纳秒或其他单位
+//  - 零值及负数时长总是返回 false，因此结果永远不会是日历单位
+func UnitFromDuration(d time.Duration) (Unit, bool) {
+    switch d {
+    case Nanosecond, Microsecond, Millisecond, Second, Minute, Hour, Day, Week:
+        return Unit(d), true
+    default:
+        return 0, false
+    }
+}
+
+// bestUnits 是 BestUnit 所使用的阈值表，按阈值降序排列，时长不小于阈值时即选用对应的单位
+var bestUnits = []struct {
+    threshold time.Duration
+    unit      Unit
+}{
+    {365 * Day, UnitYear},
+    {30 * Day, UnitMonth},
+    {Week, UnitWeek},
+    {Day, UnitDay},
+    {Hour, UnitHour},
+    {Minute, UnitMinute},
+}
+
+// BestUnit 返回适合用于展示时长 d 的最大时间单位，例如 90*time.Minute 将返回 UnitHour，适用于自适应的坐标轴刻度及人性化展示等场景。
+//
+// 时长不足一分钟时返回 UnitSecond，此后依次在达到一分钟、一小时、一天、一周、30 天及 365 天时返回
+// UnitMinute、UnitHour、UnitDay、UnitWeek、UnitMonth 及 UnitYear，恰好位于阈值上的时长将使用更大的单位。
+//
+// 关键行为说明：
+//  - 负数时长将按照其绝对值进行判断
+//  - 结果永远不会是 UnitSunday 至 UnitSaturday 等星期单位，但可能为 UnitMonth 或 UnitYear，在作为时长参与计算前应通过 IsCalendarUnit 进行判断
+//  - 月与年以 30 天及 365 天作为近似的阈值，不考虑具体的日历
+func BestUnit(d time.Duration) Unit {
+    for _, best := range bestUnits {
+        if d >= best.threshold || d <= -best.threshold {
+            return best.unit
+        }
+    }
+    return UnitSecond
+}
+
+// weekday 返回星期单位对应的 time.Weekday，当 unit 不是星期单位时第二个返回值为 false
+func (unit Unit) weekday() (time.Weekday, bool) {
+    if unit < UnitSaturday || unit > UnitSunday {
+        return 0, false
+    }
+    return time.Weekday(-unit - 1), true
+}
+
 const (
     // Nanosecond 表示时间单位纳秒，用于时间测量和计算。
     Nanosecond = time.Nanosecond
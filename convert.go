@@ -16,6 +16,7 @@ func ToMillisecond(t time.Time) int64 {
 // ToTime 将给定的毫秒数转换为UTC时间。
 //
 // mill 参数表示自 Unix 纪元以来的毫秒数，函数将此值转换为对应的时间对象。
+// 对于其他单位的时间戳，请使用 FromUnix、FromUnixMicro 及 FromUnixNano。
 //
 // 关键行为说明：
 //  - 输入为0时返回 Unix 纪元开始时刻
@@ -28,6 +29,37 @@ func ToTime(mill int64) time.Time {
     return time.Unix(0, mill*int64(time.Millisecond)).UTC()
 }
 
+// FromUnix 将自 Unix 纪元以来的秒数转换为 UTC 时间。
+//
+// 与 ToTime 一致，返回的时间始终位于 UTC 时区，如需展示为其他时区请使用 time.Time.In 进行转换。
+func FromUnix(sec int64) time.Time {
+    return time.Unix(sec, 0).UTC()
+}
+
+// FromUnixMilli 将自 Unix 纪元以来的毫秒数转换为 UTC 时间，等同于 ToTime。
+//
+// 相较于 ToTime，该函数在名称上明确了输入的单位，推荐在新代码中使用。
+func FromUnixMilli(ms int64) time.Time {
+    return time.UnixMilli(ms).UTC()
+}
+
+// FromUnixMicro 将自 Unix 纪元以来的微秒数转换为 UTC 时间。
+//
+// 与 ToTime 一致，返回的时间始终位于 UTC 时区，如需展示为其他时区请使用 time.Time.In 进行转换。
+func FromUnixMicro(us int64) time.Time {
+    return time.UnixMicro(us).UTC()
+}
+
+// FromUnixNano 将自 Unix 纪元以来的纳秒数转换为 UTC 时间。
+//
+// 与 ToTime 一致，返回的时间始终位于 UTC 时区，如需展示为其他时区请使用 time.Time.In 进行转换。
+//
+// 关键行为说明：
+//  - int64 范围内的纳秒数仅能表示 1678 年至 2262 年之间的时间
+func FromUnixNano(ns int64) time.Time {
+    return time.Unix(0, ns).UTC()
+}
+
 // Truncate 将 x 以 m 为单位进行截断，返回最接近 x 且不大于 x 的 m 的倍数。
 //
 // 参数 x 表示要截断的整数值，m 表示截断的模数。当 m 小于等于 0 时，函数直接返回 x。
@@ -42,3 +74,31 @@ func Truncate(x, m int64) int64 {
     }
     return x - x%m
 }
+
+// ToMillisecondLoc 将时间对象转换为毫秒时间戳，并同时返回其所在时区的名称。
+//
+// 该函数与 FromMillisecondLoc 配对使用，适用于需要同时存储毫秒时间戳及时区名称的场景。
+// 返回的时区名称为 t.Location().String()，例如 "Asia/Shanghai"、"UTC" 或 "Local"。
+//
+// 关键行为说明：
+//  - 毫秒以下的精度将被舍弃
+//  - 对于通过 time.FixedZone 创建的时区，其名称可能无法被 time.LoadLocation 还原
+func ToMillisecondLoc(t time.Time) (ms int64, zone string) {
+    return ToMillisecond(t), t.Location().String()
+}
+
+// FromMillisecondLoc 将毫秒时间戳转换为位于指定时区的时间对象。
+//
+// ms 参数表示自 Unix 纪元以来的毫秒数，zone 参数为时区名称，将通过 time.LoadLocation 进行加载。
+// 当时区名称无法被加载时，返回错误。
+//
+// 关键行为说明：
+//  - 转换前后所表示的时刻保持不变，仅恢复了用于展示的时区
+//  - 空字符串与 "UTC" 均表示 UTC，"Local" 表示本地时区
+func FromMillisecondLoc(ms int64, zone string) (time.Time, error) {
+    location, err := time.LoadLocation(zone)
+    if err != nil {
+        return time.Time{}, err
+    }
+    return ToTime(ms).In(location), nil
+}
@@ -0,0 +1,55 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestFromMillisecondLoc(t *testing.T) {
+    location, err := time.LoadLocation("Asia/Shanghai")
+    if err != nil {
+        t.Skip(err)
+    }
+    now := time.Date(2023, 10, 1, 12, 0, 0, 0, location)
+
+    ms, zone := chrono.ToMillisecondLoc(now)
+    result, err := chrono.FromMillisecondLoc(ms, zone)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !result.Equal(now) || result.Location().String() != location.String() {
+        t.Errorf("FromMillisecondLoc() = %v, want %v", result, now)
+    }
+
+    if _, err = chrono.FromMillisecondLoc(ms, "Invalid/Zone"); err == nil {
+        t.Errorf("FromMillisecondLoc() error = nil, want error")
+    }
+}
+
+func TestFromUnix(t *testing.T) {
+    expected := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.UTC)
+
+    var tests = []struct {
+        name     string
+        result   time.Time
+        expected time.Time
+    }{
+        {"FromUnix", chrono.FromUnix(expected.Unix()), expected.Truncate(time.Second)},
+        {"FromUnixMilli", chrono.FromUnixMilli(expected.UnixMilli()), expected.Truncate(time.Millisecond)},
+        {"FromUnixMicro", chrono.FromUnixMicro(expected.UnixMicro()), expected.Truncate(time.Microsecond)},
+        {"FromUnixNano", chrono.FromUnixNano(expected.UnixNano()), expected},
+        {"FromUnixMilliMatchesToTime", chrono.FromUnixMilli(-1500), chrono.ToTime(-1500)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if !tt.result.Equal(tt.expected) {
+                t.Errorf("%s = %v, want %v", tt.name, tt.result, tt.expected)
+            }
+            if tt.result.Location() != time.UTC {
+                t.Errorf("%s location = %v, want UTC", tt.name, tt.result.Location())
+            }
+        })
+    }
+}
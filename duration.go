@@ -0,0 +1,135 @@
+package chrono
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// ParseDuration 解析时长字符串，在 time.ParseDuration 的基础上额外支持天（d）与周（w）单位。
+//
+// 参数 s 的格式与 time.ParseDuration 一致，由可选的符号及若干个 "数值+单位" 组成，例如 "1d12h"、"-2w"、"1.5d"。
+// 天与周以固定时长进行换算，即 1d 等同于 24h，1w 等同于 7d。
+//
+// 关键行为说明：
+//  - 无法解析的字符串将返回错误
+//  - 符号仅允许出现在字符串的开头并作用于整个时长，"1d-2h" 等在中间出现符号的字符串将返回错误
+//  - 天与周不考虑夏令时等日历因素，如需按日历计算请使用 time.Time.AddDate
+func ParseDuration(s string) (time.Duration, error) {
+    orig := s
+    var neg bool
+    if s != "" && (s[0] == '-' || s[0] == '+') {
+        neg = s[0] == '-'
+        s = s[1:]
+    }
+    if s == "0" {
+        return 0, nil
+    }
+    if s == "" {
+        return 0, fmt.Errorf("chrono: invalid duration %q", orig)
+    }
+
+    var days float64
+    var rest strings.Builder
+    for s != "" {
+        i := 0
+        for i < len(s) && (s[i] == '.' || '0' <= s[i] && s[i] <= '9') {
+            i++
+        }
+        j := i
+        for j < len(s) && s[j] != '.' && (s[j] < '0' || s[j] > '9') {
+            j++
+        }
+        number, unit := s[:i], s[i:j]
+        s = s[j:]
+        if number == "" {
+            // 每个分量都必须以数值开头，从而拒绝 "1d-2h" 等混合符号的输入
+            return 0, fmt.Errorf("chrono: invalid duration %q", orig)
+        }
+
+        switch unit {
+        case "d", "w":
+            f, err := strconv.ParseFloat(number, 64)
+            if err != nil {
+                return 0, fmt.Errorf("chrono: invalid duration %q", orig)
+            }
+            if unit == "w" {
+                f *= 7
+            }
+            days += f
+        default:
+            rest.WriteString(number)
+            rest.WriteString(unit)
+        }
+    }
+
+    var d time.Duration
+    if rest.Len() > 0 {
+        var err error
+        if d, err = time.ParseDuration(rest.String()); err != nil {
+            return 0, fmt.Errorf("chrono: invalid duration %q", orig)
+        }
+    }
+    d += time.Duration(days * float64(Day))
+    if neg {
+        d = -d
+    }
+    return d, nil
+}
+
+// durationUnits 是 FormatDurationShort 所使用的时长单位，按照从大到小的顺序排列
+var durationUnits = []struct {
+    name string
+    size time.Duration
+}{
+    {"d", Day},
+    {"h", Hour},
+    {"m", Minute},
+    {"s", Second},
+    {"ms", Millisecond},
+    {"µs", Microsecond},
+    {"ns", Nanosecond},
+}
+
+// FormatDurationShort 将时长格式化为紧凑的字符串，仅保留最高位的 maxUnits 个非零单位。
+//
+// 参数 maxUnits 指定了最多保留的单位数量，当其小于等于 0 时将保留所有非零单位。
+// 例如 2*time.Hour 将被格式化为 "2h" 而非 "2h0m0s"，当 maxUnits 为 2 时 2h15m30s 将被格式化为 "2h15m"。
+// 可用的单位依次为 d、h、m、s、ms、µs、ns，不足一秒的时长将以毫秒、微秒等单位进行展示。
+//
+// 关键行为说明：
+//  - 被舍弃的低位单位将直接截断而非四舍五入，例如 90*time.Minute 在 maxUnits 为 1 时结果为 "1h" 而非 "2h"
+//  - 负数时长将以 "-" 作为前缀，零值时长的结果为 "0s"
+//
+// 使用建议：
+// 该函数仅用于界面展示，结果中的 d 单位可通过 ParseDuration 解析，但截断后的结果无法还原为原始时长。
+func FormatDurationShort(d time.Duration, maxUnits int) string {
+    if d == 0 {
+        return "0s"
+    }
+
+    var builder strings.Builder
+    u := uint64(d)
+    if d < 0 {
+        builder.WriteByte('-')
+        u = -u
+    }
+
+    var units int
+    for _, unit := range durationUnits {
+        if maxUnits > 0 && units >= maxUnits {
+            break
+        }
+        size := uint64(unit.size)
+        if n := u / size; n > 0 {
+            builder.WriteString(strconv.FormatUint(n, 10))
+            builder.WriteString(unit.name)
+            u -= n * size
+            units++
+        } else if units > 0 && u == 0 {
+            break
+        }
+    }
+    return builder.String()
+}
@@ -0,0 +1,64 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestParseDuration(t *testing.T) {
+    tests := []struct {
+        input    string
+        expected time.Duration
+        err      bool
+    }{
+        {input: "0", expected: 0},
+        {input: "1h30m", expected: 90 * time.Minute},
+        {input: "1d12h", expected: 36 * time.Hour},
+        {input: "-2w", expected: -14 * 24 * time.Hour},
+        {input: "1.5d", expected: 36 * time.Hour},
+        {input: "", err: true},
+        {input: "1x", err: true},
+        {input: "d", err: true},
+        {input: "1d-2h", err: true},
+        {input: "1h+30m", err: true},
+        {input: "--1h", err: true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.input, func(t *testing.T) {
+            result, err := chrono.ParseDuration(tt.input)
+            if (err != nil) != tt.err {
+                t.Fatalf("ParseDuration() error = %v, want error %v", err, tt.err)
+            }
+            if result != tt.expected {
+                t.Errorf("ParseDuration() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+}
+
+func TestFormatDurationShort(t *testing.T) {
+    tests := []struct {
+        d        time.Duration
+        maxUnits int
+        expected string
+    }{
+        {d: 0, maxUnits: 1, expected: "0s"},
+        {d: 2 * time.Hour, maxUnits: 2, expected: "2h"},
+        {d: 90 * time.Minute, maxUnits: 1, expected: "1h"},
+        {d: 2*time.Hour + 15*time.Minute + 30*time.Second, maxUnits: 2, expected: "2h15m"},
+        {d: 2*time.Hour + 30*time.Second, maxUnits: 2, expected: "2h30s"},
+        {d: 36 * time.Hour, maxUnits: 0, expected: "1d12h"},
+        {d: -1500 * time.Millisecond, maxUnits: 3, expected: "-1s500ms"},
+        {d: 1500 * time.Microsecond, maxUnits: 1, expected: "1ms"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.expected, func(t *testing.T) {
+            if result := chrono.FormatDurationShort(tt.d, tt.maxUnits); result != tt.expected {
+                t.Errorf("FormatDurationShort() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+}
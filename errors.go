@@ -0,0 +1,13 @@
+package chrono
+
+import "errors"
+
+// ErrUnsupportedUnit 表示传入了定义外的时间单位。
+//
+// 该错误由 StartOfE、EndOfE 等函数返回，返回的错误可能包装了具体的单位值，应通过 errors.Is 进行判断。
+var ErrUnsupportedUnit = errors.New("unsupported time unit")
+
+// ErrOffsetOutOfRange 表示传入的一天内的时刻偏移量不在 [0, 24h) 范围内。
+//
+// 该错误由 NextMomentD、ElapsedD 等函数在抛出异常时使用，异常值可能包装了具体的偏移量，应通过 errors.Is 进行判断。
+var ErrOffsetOutOfRange = errors.New("time of day offset out of range")
@@ -24,6 +24,13 @@ func StandardExponentialBackoff(count, maxRetries int, baseDelay, maxDelay time.
     return ExponentialBackoff(count, maxRetries, baseDelay, maxDelay, 2, 0.5)
 }
 
+// StandardExponentialBackoffRand 与 StandardExponentialBackoff 一致，但使用 r 作为随机化抖动的随机数来源。
+//
+// 当 r 为 nil 时使用 math/rand/v2 的全局随机数来源，详见 ExponentialBackoffRand。
+func StandardExponentialBackoffRand(r *rand.Rand, count, maxRetries int, baseDelay, maxDelay time.Duration) time.Duration {
+    return ExponentialBackoffRand(r, count, maxRetries, baseDelay, maxDelay, 2, 0.5)
+}
+
 // ExponentialBackoff 根据指数退避算法计算下一次重试的时间间隔。
 //
 // count 参数表示当前重试次数，maxRetries 指定最大重试次数，当为负数时表示无限重试。
@@ -39,13 +46,29 @@ func StandardExponentialBackoff(count, maxRetries int, baseDelay, maxDelay time.
 //  - 设置合理的 maxDelay 以防止过长的等待时间
 //  - 对于需要快速响应的场景，可以适当减小 baseDelay
 func ExponentialBackoff(count, maxRetries int, baseDelay, maxDelay time.Duration, multiplier, randomization float64) time.Duration {
+    return ExponentialBackoffRand(nil, count, maxRetries, baseDelay, maxDelay, multiplier, randomization)
+}
+
+// ExponentialBackoffRand 与 ExponentialBackoff 一致，但使用 r 作为随机化抖动的随机数来源。
+//
+// 默认的 ExponentialBackoff 使用 math/rand/v2 的全局随机数来源，其抖动无法复现。通过传入以固定种子创建的 r，
+// 可以在测试中断言确切的延迟时间，或使多个实例的抖动序列互相独立。
+//
+// 关键行为说明：
+//  - 当 r 为 nil 时使用 math/rand/v2 的全局随机数来源，此时与 ExponentialBackoff 完全一致
+//  - *rand.Rand 不是并发安全的，在多个协程中共享同一个 r 时需要自行加锁
+func ExponentialBackoffRand(r *rand.Rand, count, maxRetries int, baseDelay, maxDelay time.Duration, multiplier, randomization float64) time.Duration {
+    random := rand.Float64
+    if r != nil {
+        random = r.Float64
+    }
     for {
         if count > maxRetries && maxRetries > -1 {
             return -1
         }
 
         delay := float64(baseDelay) * math.Pow(multiplier, float64(count))
-        jitter := (rand.Float64() - 0.5) * randomization * float64(baseDelay)
+        jitter := (random() - 0.5) * randomization * float64(baseDelay)
         sleepDuration := time.Duration(delay + jitter)
 
         if sleepDuration > maxDelay {
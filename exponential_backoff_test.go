@@ -0,0 +1,34 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "math/rand/v2"
+    "testing"
+    "time"
+)
+
+func TestExponentialBackoffRand(t *testing.T) {
+    delays := func(r *rand.Rand) []time.Duration {
+        var result []time.Duration
+        for count := 0; count < 5; count++ {
+            result = append(result, chrono.StandardExponentialBackoffRand(r, count, 4, 100*time.Millisecond, time.Second))
+        }
+        return result
+    }
+
+    first := delays(rand.New(rand.NewPCG(1, 2)))
+    second := delays(rand.New(rand.NewPCG(1, 2)))
+    for i := range first {
+        if first[i] != second[i] {
+            t.Fatalf("delay %d = %v and %v from the same seed, want equal", i, first[i], second[i])
+        }
+        expected, jitter := 100*time.Millisecond<<i, 25*time.Millisecond
+        if first[i] < min(expected-jitter, time.Second) || first[i] > min(expected+jitter, time.Second) {
+            t.Errorf("delay %d = %v, want %v ± %v capped at 1s", i, first[i], expected, jitter)
+        }
+    }
+
+    if delay := chrono.ExponentialBackoffRand(rand.New(rand.NewPCG(1, 2)), 5, 4, time.Millisecond, time.Second, 2, 0.5); delay != -1 {
+        t.Errorf("ExponentialBackoffRand() after max retries = %v, want -1", delay)
+    }
+}
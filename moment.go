@@ -1,6 +1,8 @@
 package chrono
 
 import (
+    "fmt"
+    "math/rand/v2"
     "time"
 )
 
@@ -11,12 +13,12 @@ import (
 //
 // 关键行为说明：
 //  - 当前时间晚于或等于目标时刻时，返回值为次日同一时刻
-//  - 输入的时间参数不受夏令时影响，始终基于本地时区计算
+//  - 目标时刻基于 now 所在的时区计算，与 Elapsed、Future 保持一致
 //
 // 使用建议：
 //  - 确保输入的时间参数合理，避免出现无效时间组合
 func NextMoment(now time.Time, hour, min, sec int) time.Time {
-    moment := time.Date(now.Year(), now.Month(), now.Day(), hour, min, sec, 0, time.Local)
+    moment := time.Date(now.Year(), now.Month(), now.Day(), hour, min, sec, 0, now.Location())
     // 如果要检查的时刻已经过了，则返回明天的这个时刻
     if now.After(moment) || now.Equal(moment) {
         moment = moment.AddDate(0, 0, 1)
@@ -24,6 +26,52 @@ func NextMoment(now time.Time, hour, min, sec int) time.Time {
     return moment
 }
 
+// NextMomentD 与 NextMoment 相同，但目标时刻由距离午夜的偏移量 offset 表示，例如 9*time.Hour + 30*time.Minute。
+//
+// 相较于 NextMoment，offset 可以携带纳秒级的分量，避免了手动拆分为时、分、秒的繁琐与错误。
+//
+// 关键行为说明：
+//  - offset 必须位于 [0, 24h) 范围内，否则函数会抛出包装了 ErrOffsetOutOfRange 的异常
+//  - 目标时刻以时、分、秒等墙上时间分量计算，而非午夜加上固定时长，因此在夏令时切换日与 NextMoment 的行为一致
+func NextMomentD(now time.Time, offset time.Duration) time.Time {
+    moment := momentOf(now, offset)
+    if now.After(moment) || now.Equal(moment) {
+        moment = moment.AddDate(0, 0, 1)
+    }
+    return moment
+}
+
+// NextMomentJitter 与 NextMoment 相同，计算下一次到达指定时刻的时间，并在此基础上增加一个 [0, jitter) 范围内的随机偏移。
+//
+// 当大量节点均在每天的同一时刻（例如 02:00）执行任务时，通过随机偏移可以将负载分散到一段时间内，避免同时冲击后端服务。
+// 随机偏移与 ExponentialBackoff 一致，使用 math/rand/v2 的全局随机数生成器。
+//
+// 关键行为说明：
+//  - 当 jitter 小于等于 0 时，不增加任何偏移，结果与 NextMoment 相同
+//  - 当指定时刻接近午夜时，增加偏移后的时间可能落入下一个日历日
+func NextMomentJitter(now time.Time, hour, min, sec int, jitter time.Duration) time.Time {
+    moment := NextMoment(now, hour, min, sec)
+    if jitter <= 0 {
+        return moment
+    }
+    return moment.Add(time.Duration(rand.Int64N(int64(jitter))))
+}
+
+// UntilMoment 计算从 now 开始到下一次到达指定时刻所需的时长。
+//
+// 参数 now 表示当前时间，hour、min 和 sec 共同定义了每天的目标时刻。
+// 当目标时刻在今天已经过去时，将计算到明天该时刻的时长，因此该函数适用于为每日任务计算 Wheel.After 的延迟。
+//
+// 关键行为说明：
+//  - 当 now 恰好等于目标时刻时返回 0，因此结果的范围为 [0, 24h)，这与 NextMoment 在相等时返回明天该时刻不同
+//  - 跨越夏令时切换时，结果可能比 24 小时多或少一个小时
+func UntilMoment(now time.Time, hour, min, sec int) time.Duration {
+    if now.Equal(time.Date(now.Year(), now.Month(), now.Day(), hour, min, sec, 0, now.Location())) {
+        return 0
+    }
+    return NextMoment(now, hour, min, sec).Sub(now)
+}
+
 // Elapsed 判断给定的时刻是否已经过去。
 //
 // 参数 now 表示当前时间，hour、min 和 sec 分别表示指定时刻的小时、分钟和秒。
@@ -31,7 +79,7 @@ func NextMoment(now time.Time, hour, min, sec int) time.Time {
 //
 // 关键行为说明：
 //  - 当前时间与指定时刻相同视为已过期
-//  - 指定时刻基于当前日期计算，不考虑跨天情况
+//  - 指定时刻基于当前日期及 now 所在的时区计算，不考虑跨天情况
 //
 // 使用建议：
 //  - 用于判断特定时间点是否已经到达或超过
@@ -48,7 +96,7 @@ func Elapsed(now time.Time, hour, min, sec int) bool {
 //
 // 关键行为说明：
 //  - 当前时间与指定时刻相同视为已到达
-//  - 指定时刻基于当前日期计算，不考虑跨天情况
+//  - 指定时刻基于当前日期及 now 所在的时区计算，不考虑跨天情况
 //
 // 使用建议：
 //  - 用于判断特定时间点是否还未到达
@@ -57,129 +105,330 @@ func Future(now time.Time, hour, min, sec int) bool {
     return !Elapsed(now, hour, min, sec)
 }
 
+// ElapsedD 与 Elapsed 相同，但指定时刻由距离午夜的偏移量 offset 表示。
+//
+// 关键行为说明：
+//  - offset 必须位于 [0, 24h) 范围内，否则函数会抛出包装了 ErrOffsetOutOfRange 的异常
+func ElapsedD(now time.Time, offset time.Duration) bool {
+    return now.After(momentOf(now, offset))
+}
+
+// FutureD 与 Future 相同，但指定时刻由距离午夜的偏移量 offset 表示。
+//
+// 关键行为说明：
+//  - offset 必须位于 [0, 24h) 范围内，否则函数会抛出包装了 ErrOffsetOutOfRange 的异常
+func FutureD(now time.Time, offset time.Duration) bool {
+    return !ElapsedD(now, offset)
+}
+
+// momentOf 将距离午夜的偏移量拆分为时、分、秒及纳秒，并返回 now 当天在其所在时区对应的时刻
+func momentOf(now time.Time, offset time.Duration) time.Time {
+    if offset < 0 || offset >= 24*time.Hour {
+        panic(fmt.Errorf("%w: %v", ErrOffsetOutOfRange, offset))
+    }
+    hour, offset := offset/time.Hour, offset%time.Hour
+    min, offset := offset/time.Minute, offset%time.Minute
+    sec, nsec := offset/time.Second, offset%time.Second
+    return time.Date(now.Year(), now.Month(), now.Day(), int(hour), int(min), int(sec), int(nsec), now.Location())
+}
+
 // StartOf 根据给定的时间单位，计算并返回时间 t 的起始点。
 //
 // 参数 t 为需要计算的时间点。unit 用于指定时间的度量单位，如小时、天等。
-// 当 unit 为零或负值时，默认使用一天作为时间单位。
+// 当 unit 为零时，默认使用一天作为时间单位。
 // 返回的时间是根据指定单位对 t 进行向下取整后的结果。
 //
 // 关键行为说明：
 //  - 如果 t 本身已经是单位的起点，则直接返回 t
-//  - 对于定义外的单位，函数会抛出异常
+//  - 对于天及以上的单位，若当天的午夜因夏令时切换而不存在（例如历史上的 America/Sao_Paulo），将返回当天第一个有效的时刻
+//  - 对于定义外的单位，函数会抛出异常，如需以错误的形式处理请使用 StartOfE
 //
 // 使用建议：
 // 确保传递给 unit 的是一个标准的时间单位，例如 UnitDay、 UnitHour 等。
 // 避免使用自定义的时间间隔以防止潜在的错误
 func StartOf(t time.Time, unit Unit) time.Time {
-    if unit <= 0 {
+    result, err := StartOfE(t, unit)
+    if err != nil {
+        panic(err)
+    }
+    return result
+}
+
+// StartOfE 与 StartOf 相同，根据给定的时间单位计算并返回时间 t 的起始点，但在遇到定义外的单位时返回错误而非抛出异常。
+//
+// 关键行为说明：
+//  - 当 unit 为零时，默认使用一天作为时间单位
+//  - 对于定义外的单位，返回包装了 ErrUnsupportedUnit 的错误，可通过 errors.Is 进行判断
+//
+// 使用建议：
+// 当 unit 来源于配置等不受信任的输入时，优先使用该函数进行校验。
+func StartOfE(t time.Time, unit Unit) (time.Time, error) {
+    if unit == 0 {
         unit = UnitDay
     }
     switch unit {
     case UnitNanosecond:
-        return t.Truncate(Nanosecond)
+        return t.Truncate(Nanosecond), nil
     case UnitMicrosecond:
-        return t.Truncate(Microsecond)
+        return t.Truncate(Microsecond), nil
     case UnitMillisecond:
-        return t.Truncate(Millisecond)
+        return t.Truncate(Millisecond), nil
     case UnitSecond:
-        return t.Truncate(Second)
+        return t.Truncate(Second), nil
     case UnitMinute:
-        return t.Truncate(Minute)
+        return t.Truncate(Minute), nil
     case UnitHour:
-        return t.Truncate(Hour)
+        return t.Truncate(Hour), nil
     case UnitDay:
-        return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+        return startOfDay(t.Year(), t.Month(), t.Day(), t.Location()), nil
     case UnitWeek, UnitMonday, UnitTuesday, UnitWednesday, UnitThursday, UnitFriday, UnitSaturday, UnitSunday:
-        unit /= 10
-        t = StartOf(t, UnitDay)
-        tw := t.Weekday()
-        if tw == 0 {
-            tw = 7
-        }
-        d := 1 - int(tw)
-        switch unit {
-        case UnitSunday:
-            d += 6
-        default:
-            if unit == UnitWeek {
-                unit = UnitMonday
-            }
-            d += int(unit) - 1
-        }
-        return t.AddDate(0, 0, d)
+        return addDays(t, weekOffset(t, unit)), nil
     case UnitMonth:
-        return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+        return startOfDay(t.Year(), t.Month(), 1, t.Location()), nil
     case UnitYear:
-        return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+        return startOfDay(t.Year(), 1, 1, t.Location()), nil
     default:
-        panic("unsupported time unit")
+        return time.Time{}, fmt.Errorf("%w: %d", ErrUnsupportedUnit, unit)
     }
 }
 
 // EndOf 根据给定的时间单位，计算并返回时间 t 的结束点。
 //
 // 参数 t 为需要计算的时间点。unit 用于指定时间的度量单位，如小时、天等。
-// 当 unit 为零或负值时，默认使用一天作为时间单位。
+// 当 unit 为零时，默认使用一天作为时间单位。
 // 返回的时间是根据指定单位对 t 进行向上取整后的结果。
 //
 // 关键行为说明：
 //  - 如果 t 本身已经是单位的终点，则直接返回 t
-//  - 对于定义外的单位，函数会抛出异常
+//  - 对于定义外的单位，函数会抛出异常，如需以错误的形式处理请使用 EndOfE
 //
 // 使用建议：
 // 确保传递给 unit 的是一个标准的时间单位，例如 UnitDay、 UnitHour 等。
 // 避免使用自定义的时间间隔以防止潜在的错误
 func EndOf(t time.Time, unit Unit) time.Time {
-    if unit <= 0 {
+    result, err := EndOfE(t, unit)
+    if err != nil {
+        panic(err)
+    }
+    return result
+}
+
+// EndOfE 与 EndOf 相同，根据给定的时间单位计算并返回时间 t 的结束点，但在遇到定义外的单位时返回错误而非抛出异常。
+//
+// 关键行为说明：
+//  - 当 unit 为零时，默认使用一天作为时间单位
+//  - 对于定义外的单位，返回包装了 ErrUnsupportedUnit 的错误，可通过 errors.Is 进行判断
+//
+// 使用建议：
+// 当 unit 来源于配置等不受信任的输入时，优先使用该函数进行校验。
+func EndOfE(t time.Time, unit Unit) (time.Time, error) {
+    if unit == 0 {
         unit = UnitDay
     }
     switch unit {
     case UnitNanosecond:
-        return t.Truncate(Nanosecond)
+        return t.Truncate(Nanosecond), nil
     case UnitMicrosecond:
-        return t.Truncate(Microsecond).Add(Microsecond - 1)
+        return t.Truncate(Microsecond).Add(Microsecond - 1), nil
     case UnitMillisecond:
-        return t.Truncate(Millisecond).Add(Millisecond - 1)
+        return t.Truncate(Millisecond).Add(Millisecond - 1), nil
     case UnitSecond:
-        return t.Truncate(Second).Add(Second - 1)
+        return t.Truncate(Second).Add(Second - 1), nil
     case UnitMinute:
-        return t.Truncate(Minute).Add(Minute - 1)
+        return t.Truncate(Minute).Add(Minute - 1), nil
     case UnitHour:
-        return t.Truncate(Hour).Add(Hour - 1)
+        return t.Truncate(Hour).Add(Hour - 1), nil
     case UnitDay:
-        return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
+        return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location()), nil
     case UnitWeek, UnitMonday, UnitTuesday, UnitWednesday, UnitThursday, UnitFriday, UnitSaturday, UnitSunday:
-        unit /= 10
         t = EndOf(t, UnitDay)
-        tw := t.Weekday()
-        if tw == 0 {
-            tw = 7
-        }
-        d := 1 - int(tw)
-        switch unit {
-        case UnitSunday:
-            d += 6
-        default:
-            if unit == UnitWeek {
-                unit = UnitMonday
-            }
-            d += int(unit) - 1
-        }
-        return EndOf(t.AddDate(0, 0, d), UnitDay)
+        return EndOf(t.AddDate(0, 0, weekOffset(t, unit)), UnitDay), nil
     case UnitMonth:
-        return StartOf(t, unit).AddDate(0, 1, 0).Add(-time.Nanosecond)
+        return StartOf(t, unit).AddDate(0, 1, 0).Add(-time.Nanosecond), nil
     case UnitYear:
-        return StartOf(t, unit).AddDate(1, 0, 0).Add(-time.Nanosecond)
+        return StartOf(t, unit).AddDate(1, 0, 0).Add(-time.Nanosecond), nil
+    default:
+        return time.Time{}, fmt.Errorf("%w: %d", ErrUnsupportedUnit, unit)
+    }
+}
+
+// StartOfUTC 与 StartOf 相同，但在 UTC 时区中计算时间 t 的起始点，并返回 UTC 时间。
+//
+// 对于天及以上的单位，StartOf 的结果取决于 t 所在的时区，而 StartOfUTC 对于同一时刻总是返回相同的结果，
+// 适用于跨时区部署的服务按照 UTC 日期统计数据等需要稳定分桶键的场景。
+//
+// 关键行为说明：
+//  - 对于定义外的单位，函数会抛出异常，与 StartOf 的行为一致
+func StartOfUTC(t time.Time, unit Unit) time.Time {
+    return StartOf(t.UTC(), unit)
+}
+
+// EndOfUTC 与 EndOf 相同，但在 UTC 时区中计算时间 t 的结束点，并返回 UTC 时间，详见 StartOfUTC。
+func EndOfUTC(t time.Time, unit Unit) time.Time {
+    return EndOf(t.UTC(), unit)
+}
+
+// NextStartOf 计算并返回严格晚于时间 t 的下一个时间单位起始点。
+//
+// 参数 t 为需要计算的时间点，unit 用于指定时间的度量单位，起始点的计算规则与 StartOf 一致。
+// 例如 unit 为 UnitMinute 时返回下一个整分钟，unit 为 UnitMonday 时返回下一个周一的零点。
+//
+// 关键行为说明：
+//  - 即使 t 本身已经是单位的起点，也将返回下一个起点
+//  - 月、年及星期等日历单位按照日历进行推进，不受月份天数及夏令时的影响
+//  - 对于定义外的单位，函数会抛出异常
+func NextStartOf(t time.Time, unit Unit) time.Time {
+    start := StartOf(t, unit)
+    if start.After(t) {
+        return start
+    }
+    switch {
+    case unit == UnitYear:
+        return startOfDay(start.Year()+1, 1, 1, start.Location())
+    case unit == UnitMonth:
+        return addDays(start, MonthDays(start))
+    case unit == UnitWeek || IsCalendarUnit(unit):
+        return addDays(start, 7)
+    case unit == UnitDay || unit == 0:
+        return addDays(start, 1)
     default:
-        panic("unsupported time unit")
+        return start.Add(time.Duration(unit))
     }
 }
 
+// EachMonth 依次遍历 start 至 end 之间每个月份的起始时刻，并以此调用 fn。
+//
+// 遍历从 StartOf(start, UnitMonth) 开始，到 end 所在月份的起始时刻结束（包含）。当 fn 返回 false 时将提前终止遍历。
+// 相较于对时间段进行拆分，该函数适用于仅需要每个月份的锚点的场景，例如生成月度报表的键。
+//
+// 关键行为说明：
+//  - 月份的推进基于每月的 1 日进行，不会出现 1 月 31 日推进一个月后溢出至 3 月的问题
+//  - 当 start 晚于 end 时，两者将被交换
+//  - 月份的起始时刻基于 start 所在的时区进行计算
+func EachMonth(start, end time.Time, fn func(monthStart time.Time) bool) {
+    start, end = SmallerFirst(start, end)
+    last := StartOf(end.In(start.Location()), UnitMonth)
+    for month := StartOf(start, UnitMonth); !month.After(last); month = NextStartOf(month, UnitMonth) {
+        if !fn(month) {
+            return
+        }
+    }
+}
+
+// startOfDay 返回指定日期在 loc 中第一个有效的时刻。
+//
+// 在部分时区中，夏令时会在午夜开始，此时 time.Date 构造的午夜是不存在的墙上时间，将被规范化至前一天的 23:00 或当天的 01:00，
+// 该函数会在这种情况下通过二分查找定位到时区切换的时刻，即当天真正的开始时间。
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+    t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+    if y, m, d := t.Date(); y == year && m == month && d == day && t.Hour() == 0 {
+        return t
+    }
+
+    sameDay := func(unix int64) bool {
+        y, m, d := time.Unix(unix, 0).In(loc).Date()
+        return y == year && m == month && d == day
+    }
+    lo := time.Date(year, month, day-1, 12, 0, 0, 0, loc).Unix()
+    hi := time.Date(year, month, day, 12, 0, 0, 0, loc).Unix()
+    for hi-lo > 1 {
+        mid := lo + (hi-lo)/2
+        if sameDay(mid) {
+            hi = mid
+        } else {
+            lo = mid
+        }
+    }
+    return time.Unix(hi, 0).In(loc)
+}
+
+// addDays 返回 t 所在日期之后第 days 天的第一个有效时刻，days 为负数时表示之前
+func addDays(t time.Time, days int) time.Time {
+    // 以正午作为基准进行日期推进，避免落入因夏令时切换而不存在的墙上时间
+    y, m, d := time.Date(t.Year(), t.Month(), t.Day()+days, 12, 0, 0, 0, t.Location()).Date()
+    return startOfDay(y, m, d, t.Location())
+}
+
+// weekOffset 以周一作为一周的开始，计算从 t 所在日期到同一周内 unit 所表示的星期的天数偏移，UnitWeek 等同于 UnitMonday
+func weekOffset(t time.Time, unit Unit) int {
+    target, ok := unit.weekday()
+    if !ok {
+        target = time.Monday
+    }
+    tw, tt := int(t.Weekday()), int(target)
+    if tw == 0 {
+        tw = 7
+    }
+    if tt == 0 {
+        tt = 7
+    }
+    return tt - tw
+}
+
 // Zero 返回表示时间零值的Time对象，用于初始化或比较。
 func Zero() time.Time {
     return zero
 }
 
+// StripMono 移除时间 t 中携带的单调时钟读数，等同于 t.Round(0)，其余信息保持不变。
+//
+// 通过 time.Now() 获取的时间携带单调时钟读数，当比较或相减的两个时间均携带该读数时，结果基于单调时钟计算，
+// 否则基于墙上时间计算。因此当系统时钟发生跳变后，"两个均来源于 time.Now() 的时间" 与 "其中一个经过解析或序列化的时间"
+// 可能得到不同的比较结果。在比较来源不同的时间前，可以通过该函数统一为墙上时间。
+//
+// 关键行为说明：
+//  - 不携带单调时钟读数的时间将原样返回
+//  - 单调时钟读数仅在当前进程内有效，需要测量经过的时长时应保留该读数
+func StripMono(t time.Time) time.Time {
+    return t.Round(0)
+}
+
+// Now 返回截断至 unit 精度的当前时间，即 StartOf(time.Now(), unit)，例如 Now(UnitMillisecond) 返回毫秒精度的当前时间。
+//
+// 适用于以固定精度存储时间戳的场景，避免了多余的精度及单调时钟读数导致的比较失败。
+//
+// 关键行为说明：
+//  - 天以下的单位通过 time.Time.Truncate 进行截断，天及以上的单位通过 time.Date 重新构造，两者均不携带单调时钟读数
+//  - Truncate 基于绝对时间进行截断，对于与 UTC 的偏移量不是整小时的时区，UnitHour 的截断结果并非本地时间的整点
+//  - 当 unit 为零时，默认使用一天作为时间单位，对于定义外的单位，函数会抛出异常，与 StartOf 的行为一致
+func Now(unit Unit) time.Time {
+    return StartOf(time.Now(), unit)
+}
+
+// StartOfToday 返回本地时区中今天的零点，等价于 StartOf(time.Now(), UnitDay)。
+//
+// 关键行为说明：
+//  - 返回的时间通过 time.Date 构造，不携带单调时钟读数，可以直接与存储的日期进行比较
+//  - 零点因夏令时切换而不存在时，将返回当天第一个有效的时刻，与 StartOf 的行为一致
+func StartOfToday() time.Time {
+    return StartOfTodayIn(time.Local)
+}
+
+// StartOfTomorrow 返回本地时区中明天的零点，详见 StartOfToday。
+func StartOfTomorrow() time.Time {
+    return StartOfTomorrowIn(time.Local)
+}
+
+// StartOfYesterday 返回本地时区中昨天的零点，详见 StartOfToday。
+func StartOfYesterday() time.Time {
+    return StartOfYesterdayIn(time.Local)
+}
+
+// StartOfTodayIn 返回 loc 时区中今天的零点，详见 StartOfToday。
+func StartOfTodayIn(loc *time.Location) time.Time {
+    return StartOf(time.Now().In(loc), UnitDay)
+}
+
+// StartOfTomorrowIn 返回 loc 时区中明天的零点，日期按照日历推进，不受夏令时导致的单日时长变化影响。
+func StartOfTomorrowIn(loc *time.Location) time.Time {
+    return addDays(StartOfTodayIn(loc), 1)
+}
+
+// StartOfYesterdayIn 返回 loc 时区中昨天的零点，日期按照日历推进，不受夏令时导致的单日时长变化影响。
+func StartOfYesterdayIn(loc *time.Location) time.Time {
+    return addDays(StartOfTodayIn(loc), -1)
+}
+
 // Max 返回两个时间点中较晚的那个。
 //
 // 该函数接受两个 time.Time 类型参数，比较它们的时间先后，并返回较晚的一个。如果两个时间相等，则返回任一参数。
@@ -190,6 +439,7 @@ func Zero() time.Time {
 //
 // 关键行为说明：
 //  - 如果 t1 和 t2 相等，函数将返回 t1
+//  - 与 time.Time.After 一致，当两者均携带单调时钟读数时基于单调时钟比较，否则基于墙上时间比较，详见 StripMono
 func Max(t1, t2 time.Time) time.Time {
     if t1.After(t2) {
         return t1
@@ -203,7 +453,7 @@ func Max(t1, t2 time.Time) time.Time {
 //
 // 关键行为说明：
 //  - 如果两个时间相等，将返回第一个参数 t1
-//  - 时间点的比较基于 Go 的 time.Time 类型定义
+//  - 时间点的比较基于 Go 的 time.Time 类型定义，当两者均携带单调时钟读数时基于单调时钟比较，否则基于墙上时间比较，详见 StripMono
 func Min(t1, t2 time.Time) time.Time {
     if t1.Before(t2) {
         return t1
@@ -259,13 +509,59 @@ func SmallerLast(t1, t2 time.Time) (time.Time, time.Time) {
 // 关键行为说明：
 //  - 时间差以 time.Duration 类型返回，单位为纳秒
 //  - 当两个时间点相同时，返回的时间差为零
+//  - 计算前将通过 StripMono 移除两者的单调时钟读数，因此结果始终基于墙上时间，不受参数是否来源于 time.Now() 的影响
 func Delta(t1, t2 time.Time) time.Duration {
+    t1, t2 = StripMono(t1), StripMono(t2)
     if t1.Before(t2) {
         return t2.Sub(t1)
     }
     return t1.Sub(t2)
 }
 
+// CalendarDiff 按照日历计算从 start 到 end 之间相差的年、月、日，以及不足一天的剩余时长。
+//
+// 与基于固定时长的 Delta 不同，该函数按照日历逐级计算，例如从 1 月 15 日至次年 3 月 15 日将返回 1 年 2 个月，而非 425 天。
+// 计算基于 start 所在的时区进行，end 将被转换至该时区。
+//
+// 关键行为说明：
+//  - 当 start 为月末且目标月份天数不足时，将以目标月份的最后一天作为对齐点，例如 1 月 31 日至 2 月 28 日视为 1 个月
+//  - 当 end 早于 start 时，所有返回值均为 CalendarDiff(end, start) 的相反数
+//  - 跨越夏令时切换时，不足一天的剩余时长将按实际经过的时长计算
+func CalendarDiff(start, end time.Time) (years, months, days int, rest time.Duration) {
+    if end.Before(start) {
+        years, months, days, rest = CalendarDiff(end, start)
+        return -years, -months, -days, -rest
+    }
+    end = end.In(start.Location())
+
+    total := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+    anchor := addMonths(start, total)
+    if anchor.After(end) {
+        total--
+        anchor = addMonths(start, total)
+    }
+
+    ay, am, ad := anchor.Date()
+    ey, em, ed := end.Date()
+    days = int(time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)) / Day)
+    next := anchor.AddDate(0, 0, days)
+    if next.After(end) {
+        days--
+        next = anchor.AddDate(0, 0, days)
+    }
+    return total / 12, total % 12, days, end.Sub(next)
+}
+
+// addMonths 返回 t 之后第 months 个月的同一日期及时刻，当目标月份天数不足时对齐至该月最后一天
+func addMonths(t time.Time, months int) time.Time {
+    y, m, d := t.Date()
+    first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+    if days := MonthDays(first); d > days {
+        d = days
+    }
+    return time.Date(first.Year(), first.Month(), d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
 // MonthDays 返回给定时间的月份天数。
 //
 // 参数 t 影响函数行为，它决定了返回哪个月份的天数。对于非二月，特定月份有固定的天数：4、6、9 和 11 月为 30 天，其他月份为 31 天。对于二月，根据年份是否为闰年来决定天数：普通年份 28 天，闰年 29 天。
@@ -289,3 +585,152 @@ func MonthDays(t time.Time) int {
     }
     return 28
 }
+
+// WeekOfMonth 返回时间 t 在其所在月份中属于第几周，从 1 开始计数。
+//
+// 参数 weekStart 指定了每周的第一天，月份的第一周为包含该月 1 日的那一周，即便这一周并不完整。
+// 例如当 weekStart 为 time.Monday 且 1 日为周三时，1 日至 5 日属于第一周，6 日（周一）开始属于第二周。
+//
+// 关键行为说明：
+//  - 周的划分仅基于日期，与 t 的时分秒无关
+//
+// 使用建议：
+// 可与 WeeksInMonth 搭配使用，实现 "第一个周一"、"最后一个周五" 等日历展示需求。
+func WeekOfMonth(t time.Time, weekStart time.Weekday) int {
+    return (t.Day()-1+monthWeekOffset(t, weekStart))/7 + 1
+}
+
+// WeeksInMonth 返回时间 t 所在月份按照 weekStart 划分后所包含的周数，不完整的首尾周同样计入。
+//
+// 参数 weekStart 指定了每周的第一天，划分规则与 WeekOfMonth 一致，结果等同于该月最后一天的 WeekOfMonth。
+func WeeksInMonth(t time.Time, weekStart time.Weekday) int {
+    return (MonthDays(t)-1+monthWeekOffset(t, weekStart))/7 + 1
+}
+
+// MonthGrid 返回用于渲染时间 t 所在月份月视图的 6 行 7 列日期网格，每个元素均为当天的起始时刻。
+//
+// 参数 weekStart 指定了每周的第一天，即每一行的第一列，网格从包含该月 1 日的那一周开始，
+// 首尾不足的部分将以上个月及下个月的日期进行填充。周的划分规则与 WeekOfMonth 一致。
+//
+// 关键行为说明：
+//  - 网格始终为 6 行，以保证不同月份的视图高度一致，WeeksInMonth 小于 6 的月份末尾将以下个月的完整周进行填充
+//  - 当 1 日恰好为 weekStart 时，网格的第一个元素即为 1 日，不存在上个月的日期
+//  - 日期基于 t 所在的时区进行计算，零点因夏令时切换而不存在时，将使用当天第一个有效的时刻，与 StartOf 的行为一致
+func MonthGrid(t time.Time, weekStart time.Weekday) [][]time.Time {
+    start := addDays(StartOf(t, UnitMonth), -monthWeekOffset(t, weekStart))
+    grid := make([][]time.Time, 6)
+    for week := range grid {
+        grid[week] = make([]time.Time, 7)
+        for day := range grid[week] {
+            grid[week][day] = addDays(start, week*7+day)
+        }
+    }
+    return grid
+}
+
+// monthWeekOffset 返回 t 所在月份的 1 日距离其所在周第一天的天数
+func monthWeekOffset(t time.Time, weekStart time.Weekday) int {
+    first := StartOf(t, UnitMonth)
+    return (int(first.Weekday()) - int(weekStart) + 7) % 7
+}
+
+// CountWeekday 返回闭区间 [start, end] 内的日历日期中星期为 weekday 的天数，例如 "本月有几个周五"。
+//
+// 日期基于 start 所在的时区进行计算，与 start 及 end 的时分秒无关。计算通过算术完成而非逐日遍历，因此适用于跨度较大的区间。
+//
+// 关键行为说明：
+//  - start 与 end 位于同一天时，仅判断该天的星期
+//  - 当 end 所在的日期早于 start 所在的日期时返回 0
+//  - 不受夏令时导致的单日时长变化影响
+func CountWeekday(start, end time.Time, weekday time.Weekday) int {
+    sy, sm, sd := start.Date()
+    ey, em, ed := end.In(start.Location()).Date()
+    first := time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC)
+    // 通过 Unix 秒数计算天数差，以避免 time.Duration 在跨度超过约 292 年时溢出
+    days := int((time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC).Unix()-first.Unix())/int64(Day/Second)) + 1
+    if days <= 0 {
+        return 0
+    }
+    count := days / 7
+    if (int(weekday)-int(first.Weekday())+7)%7 < days%7 {
+        count++
+    }
+    return count
+}
+
+// IsWeekend 判断时间 t 在其所在时区中是否为周六或周日。
+func IsWeekend(t time.Time) bool {
+    weekday := t.Weekday()
+    return weekday == time.Saturday || weekday == time.Sunday
+}
+
+// ToNearestWeekday 当时间 t 位于周末时，返回距离其最近的工作日中的同一时刻，否则原样返回 t。
+//
+// 周六将被调整至前一天的周五，周日将被调整至后一天的周一，适用于节假日调整等需要避开周末的场景。
+//
+// 关键行为说明：
+//  - 日期基于 t 所在的时区进行计算，调整时保留墙上时间的时分秒，而非固定加减 24 小时
+//  - 与 time.Time.AddDate 一致，当保留的时刻因夏令时切换而不存在时将被规范化
+//
+// 使用建议：
+// 如需始终向后或向前调整，例如金融日期约定中的 following 与 preceding，请使用 ToNextWeekday 或 ToPrevWeekday。
+func ToNearestWeekday(t time.Time) time.Time {
+    switch t.Weekday() {
+    case time.Saturday:
+        return t.AddDate(0, 0, -1)
+    case time.Sunday:
+        return t.AddDate(0, 0, 1)
+    default:
+        return t
+    }
+}
+
+// ToNextWeekday 当时间 t 位于周末时，返回其后第一个工作日（即周一）中的同一时刻，否则原样返回 t，详见 ToNearestWeekday。
+func ToNextWeekday(t time.Time) time.Time {
+    switch t.Weekday() {
+    case time.Saturday:
+        return t.AddDate(0, 0, 2)
+    case time.Sunday:
+        return t.AddDate(0, 0, 1)
+    default:
+        return t
+    }
+}
+
+// ToPrevWeekday 当时间 t 位于周末时，返回其前最后一个工作日（即周五）中的同一时刻，否则原样返回 t，详见 ToNearestWeekday。
+func ToPrevWeekday(t time.Time) time.Time {
+    switch t.Weekday() {
+    case time.Saturday:
+        return t.AddDate(0, 0, -1)
+    case time.Sunday:
+        return t.AddDate(0, 0, -2)
+    default:
+        return t
+    }
+}
+
+// Quarter 返回时间 t 所在的日历季度，取值范围为 1 至 4，例如 1 至 3 月为第 1 季度。
+func Quarter(t time.Time) int {
+    return (int(t.Month())-1)/3 + 1
+}
+
+// FiscalQuarter 返回时间 t 在以 fiscalStart 为起始月份的财年中所属的财年及季度。
+//
+// 返回的 year 为该财年起始月份所在的日历年，quarter 的取值范围为 1 至 4。
+// 例如财年自 7 月开始时，2024 年 3 月属于 2023 年 7 月开始的财年的第 3 季度，将返回 (2023, 3)。
+//
+// 关键行为说明：
+//  - 当 fiscalStart 为 time.January 时，结果与 t.Year() 及 Quarter 一致
+//  - 当 fiscalStart 不在 1 至 12 的范围内时，将视为 time.January
+//  - 部分机构以财年结束时所在的日历年命名财年，此时需要自行在 year 的基础上加 1
+func FiscalQuarter(t time.Time, fiscalStart time.Month) (year, quarter int) {
+    if fiscalStart < time.January || fiscalStart > time.December {
+        fiscalStart = time.January
+    }
+    year = t.Year()
+    if t.Month() < fiscalStart {
+        year--
+    }
+    offset := (int(t.Month()) - int(fiscalStart) + 12) % 12
+    return year, offset/3 + 1
+}
@@ -1,8 +1,10 @@
 package chrono_test
 
 import (
+    "errors"
     "fmt"
     "github.com/kercylan98/chrono"
+    "math"
     "testing"
     "time"
 )
@@ -15,6 +17,23 @@ func TestDelta(t *testing.T) {
     fmt.Println(a.AddDate(0, 0, -7))
 }
 
+func TestDeltaMonotonic(t *testing.T) {
+    now := time.Now()
+    parsed, err := time.Parse(time.RFC3339Nano, now.Add(90*time.Minute).Format(time.RFC3339Nano))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if stripped := chrono.StripMono(now); stripped == now || !stripped.Equal(now) {
+        t.Errorf("StripMono() = %v, want the same instant without a monotonic reading", stripped)
+    }
+    for _, pair := range [][2]time.Time{{now, parsed}, {parsed, now}} {
+        if delta := chrono.Delta(pair[0], pair[1]); delta != 90*time.Minute {
+            t.Errorf("Delta(%v, %v) = %v, want 1h30m", pair[0], pair[1], delta)
+        }
+    }
+}
+
 func TestNextMoment(t *testing.T) {
     tests := []struct {
         name     string
@@ -186,6 +205,12 @@ func TestStartOf(t *testing.T) {
             unit:     chrono.UnitDay,
             expected: time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local),
         },
+        {
+            name:     "Week",
+            now:      time.Date(2023, 10, 1, 12, 1, 1, 123456789, time.Local),
+            unit:     chrono.UnitWeek,
+            expected: time.Date(2023, 9, 25, 0, 0, 0, 0, time.Local),
+        },
         {
             name:     "Week (Monday)",
             now:      time.Date(2023, 10, 1, 12, 1, 1, 123456789, time.Local),
@@ -427,3 +452,695 @@ func TestCeilDeltaDays(t *testing.T) {
         })
     }
 }
+
+func TestStartOfE(t *testing.T) {
+    now := time.Date(2023, 10, 1, 12, 1, 1, 0, time.Local)
+
+    if _, err := chrono.StartOfE(now, chrono.Unit(12345)); !errors.Is(err, chrono.ErrUnsupportedUnit) {
+        t.Errorf("StartOfE() error = %v, want %v", err, chrono.ErrUnsupportedUnit)
+    }
+    if _, err := chrono.EndOfE(now, chrono.Unit(12345)); !errors.Is(err, chrono.ErrUnsupportedUnit) {
+        t.Errorf("EndOfE() error = %v, want %v", err, chrono.ErrUnsupportedUnit)
+    }
+
+    result, err := chrono.StartOfE(now, chrono.UnitDay)
+    if err != nil || !result.Equal(chrono.StartOf(now, chrono.UnitDay)) {
+        t.Errorf("StartOfE() = %v, %v, want %v", result, err, chrono.StartOf(now, chrono.UnitDay))
+    }
+}
+
+func TestStartOfUTC(t *testing.T) {
+    tokyo, err := time.LoadLocation("Asia/Tokyo")
+    if err != nil {
+        t.Skip(err)
+    }
+    // 东京时间 2023-10-01 00:30 即 UTC 2023-09-30 15:30
+    now := time.Date(2023, 10, 1, 0, 30, 0, 0, tokyo)
+
+    var tests = []struct {
+        name     string
+        boundary func(time.Time, chrono.Unit) time.Time
+        unit     chrono.Unit
+        expected time.Time
+        local    time.Time
+    }{
+        {"StartOfDay", chrono.StartOfUTC, chrono.UnitDay, time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC), time.Date(2023, 10, 1, 0, 0, 0, 0, tokyo)},
+        {"EndOfDay", chrono.EndOfUTC, chrono.UnitDay, time.Date(2023, 9, 30, 23, 59, 59, 999999999, time.UTC), time.Date(2023, 10, 1, 23, 59, 59, 999999999, tokyo)},
+        {"StartOfMonth", chrono.StartOfUTC, chrono.UnitMonth, time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 10, 1, 0, 0, 0, 0, tokyo)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := tt.boundary(now, tt.unit)
+            if !result.Equal(tt.expected) || result.Location() != time.UTC {
+                t.Errorf("result = %v, want %v", result, tt.expected)
+            }
+            if result.Equal(tt.local) {
+                t.Errorf("UTC boundary %v equals the local boundary, want them to differ", result)
+            }
+        })
+    }
+
+    if local := chrono.StartOf(now, chrono.UnitDay); !local.Equal(time.Date(2023, 10, 1, 0, 0, 0, 0, tokyo)) {
+        t.Errorf("StartOf() = %v, want the Tokyo midnight", local)
+    }
+}
+
+func TestNow(t *testing.T) {
+    var tests = []struct {
+        name string
+        unit chrono.Unit
+    }{
+        {"Millisecond", chrono.UnitMillisecond},
+        {"Second", chrono.UnitSecond},
+        {"Day", chrono.UnitDay},
+        {"Month", chrono.UnitMonth},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            before := time.Now()
+            now := chrono.Now(tt.unit)
+            if !now.Equal(chrono.StartOf(now, tt.unit)) {
+                t.Errorf("Now() = %v is not truncated to the unit", now)
+            }
+            if now.After(time.Now()) || now.Before(chrono.StartOf(before, tt.unit)) {
+                t.Errorf("Now() = %v is outside the current unit", now)
+            }
+            if now != chrono.StripMono(now) {
+                t.Errorf("Now() = %v carries a monotonic clock reading", now)
+            }
+        })
+    }
+}
+
+func TestStartOfToday(t *testing.T) {
+    tokyo, err := time.LoadLocation("Asia/Tokyo")
+    if err != nil {
+        t.Skip(err)
+    }
+
+    var tests = []struct {
+        name     string
+        boundary func() time.Time
+        loc      *time.Location
+        days     int
+    }{
+        {"Today", chrono.StartOfToday, time.Local, 0},
+        {"Tomorrow", chrono.StartOfTomorrow, time.Local, 1},
+        {"Yesterday", chrono.StartOfYesterday, time.Local, -1},
+        {"TodayIn", func() time.Time { return chrono.StartOfTodayIn(tokyo) }, tokyo, 0},
+        {"TomorrowIn", func() time.Time { return chrono.StartOfTomorrowIn(tokyo) }, tokyo, 1},
+        {"YesterdayIn", func() time.Time { return chrono.StartOfYesterdayIn(tokyo) }, tokyo, -1},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            before := time.Now().In(tt.loc)
+            result := tt.boundary()
+            after := time.Now().In(tt.loc)
+
+            // 调用期间可能恰好跨越午夜，此时结果可以是前后任一天
+            matched := false
+            for _, now := range []time.Time{before, after} {
+                y, m, d := now.Date()
+                if result.Equal(time.Date(y, m, d+tt.days, 0, 0, 0, 0, tt.loc)) {
+                    matched = true
+                }
+            }
+            if !matched {
+                t.Errorf("result = %v, want midnight %d days from %v", result, tt.days, before)
+            }
+            if result.Location() != tt.loc {
+                t.Errorf("result location = %v, want %v", result.Location(), tt.loc)
+            }
+            if result != chrono.StripMono(result) {
+                t.Errorf("result = %v carries a monotonic clock reading", result)
+            }
+        })
+    }
+}
+
+func TestIsCalendarUnit(t *testing.T) {
+    for _, unit := range []chrono.Unit{chrono.UnitSunday, chrono.UnitSaturday, chrono.UnitMonth, chrono.UnitYear} {
+        if !chrono.IsCalendarUnit(unit) {
+            t.Errorf("IsCalendarUnit(%d) = false, want true", unit)
+        }
+    }
+    for _, unit := range []chrono.Unit{0, chrono.UnitNanosecond, chrono.UnitDay, chrono.UnitWeek, -10} {
+        if chrono.IsCalendarUnit(unit) {
+            t.Errorf("IsCalendarUnit(%d) = true, want false", unit)
+        }
+    }
+}
+
+func TestBestUnit(t *testing.T) {
+    const day = 24 * time.Hour
+    var tests = []struct {
+        duration time.Duration
+        unit     chrono.Unit
+    }{
+        {0, chrono.UnitSecond},
+        {time.Millisecond, chrono.UnitSecond},
+        {59 * time.Second, chrono.UnitSecond},
+        {60 * time.Second, chrono.UnitMinute},
+        {59*time.Minute + 59*time.Second, chrono.UnitMinute},
+        {time.Hour, chrono.UnitHour},
+        {day - time.Nanosecond, chrono.UnitHour},
+        {day, chrono.UnitDay},
+        {7 * day, chrono.UnitWeek},
+        {30*day - time.Nanosecond, chrono.UnitWeek},
+        {30 * day, chrono.UnitMonth},
+        {365 * day, chrono.UnitYear},
+        {-90 * time.Minute, chrono.UnitHour},
+        {math.MinInt64, chrono.UnitYear},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.duration.String(), func(t *testing.T) {
+            unit := chrono.BestUnit(tt.duration)
+            if unit != tt.unit {
+                t.Errorf("BestUnit(%v) = %d, want %d", tt.duration, unit, tt.unit)
+            }
+            if unit >= chrono.UnitSaturday && unit <= chrono.UnitSunday {
+                t.Errorf("BestUnit(%v) returned weekday unit %d", tt.duration, unit)
+            }
+        })
+    }
+}
+
+func TestUnitFromDuration(t *testing.T) {
+    var tests = []struct {
+        duration time.Duration
+        unit     chrono.Unit
+        ok       bool
+    }{
+        {time.Nanosecond, chrono.UnitNanosecond, true},
+        {time.Millisecond, chrono.UnitMillisecond, true},
+        {time.Hour, chrono.UnitHour, true},
+        {7 * 24 * time.Hour, chrono.UnitWeek, true},
+        {10 * time.Nanosecond, 0, false},
+        {90 * time.Minute, 0, false},
+        {0, 0, false},
+        {-time.Nanosecond, 0, false},
+        {time.Duration(chrono.UnitMonth), 0, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.duration.String(), func(t *testing.T) {
+            unit, ok := chrono.UnitFromDuration(tt.duration)
+            if unit != tt.unit || ok != tt.ok {
+                t.Errorf("UnitFromDuration(%v) = %d, %v, want %d, %v", tt.duration, unit, ok, tt.unit, tt.ok)
+            }
+            if ok && chrono.IsCalendarUnit(unit) {
+                t.Errorf("UnitFromDuration(%v) returned calendar unit %d", tt.duration, unit)
+            }
+        })
+    }
+
+    if _, err := chrono.StartOfE(time.Now(), chrono.Unit(10)); !errors.Is(err, chrono.ErrUnsupportedUnit) {
+        t.Errorf("StartOfE(Unit(10)) error = %v, want ErrUnsupportedUnit", err)
+    }
+}
+
+func TestWeekOfMonth(t *testing.T) {
+    tests := []struct {
+        name      string
+        now       time.Time
+        weekStart time.Weekday
+        week      int
+        weeks     int
+    }{
+        {
+            // 2024-01-01 为周一
+            name:      "First day on week start",
+            now:       time.Date(2024, 1, 8, 12, 0, 0, 0, time.Local),
+            weekStart: time.Monday,
+            week:      2,
+            weeks:     5,
+        },
+        {
+            // 2023-11-01 为周三
+            name:      "First day mid-week",
+            now:       time.Date(2023, 11, 6, 12, 0, 0, 0, time.Local),
+            weekStart: time.Monday,
+            week:      2,
+            weeks:     5,
+        },
+        {
+            name:      "First day mid-week last day",
+            now:       time.Date(2023, 11, 5, 12, 0, 0, 0, time.Local),
+            weekStart: time.Monday,
+            week:      1,
+            weeks:     5,
+        },
+        {
+            // 2026-02-01 为周日，共 28 天
+            name:      "Sunday start",
+            now:       time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local),
+            weekStart: time.Sunday,
+            week:      4,
+            weeks:     4,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if result := chrono.WeekOfMonth(tt.now, tt.weekStart); result != tt.week {
+                t.Errorf("WeekOfMonth() = %v, want %v", result, tt.week)
+            }
+            if result := chrono.WeeksInMonth(tt.now, tt.weekStart); result != tt.weeks {
+                t.Errorf("WeeksInMonth() = %v, want %v", result, tt.weeks)
+            }
+        })
+    }
+}
+
+func TestMonthGrid(t *testing.T) {
+    tests := []struct {
+        name      string
+        now       time.Time
+        weekStart time.Weekday
+        first     time.Time
+        last      time.Time
+    }{
+        {
+            // 2024-01-01 为周一
+            name:      "First day on week start",
+            now:       time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+            weekStart: time.Monday,
+            first:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+            last:      time.Date(2024, 2, 11, 0, 0, 0, 0, time.UTC),
+        },
+        {
+            // 2023-11-01 为周三
+            name:      "First day mid-week",
+            now:       time.Date(2023, 11, 30, 23, 0, 0, 0, time.UTC),
+            weekStart: time.Monday,
+            first:     time.Date(2023, 10, 30, 0, 0, 0, 0, time.UTC),
+            last:      time.Date(2023, 12, 10, 0, 0, 0, 0, time.UTC),
+        },
+        {
+            // 2026-02-01 为周日，共 28 天，末尾两周均来自下个月
+            name:      "Sunday start",
+            now:       time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+            weekStart: time.Sunday,
+            first:     time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+            last:      time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            grid := chrono.MonthGrid(tt.now, tt.weekStart)
+            if len(grid) != 6 {
+                t.Fatalf("MonthGrid() has %d weeks, want 6", len(grid))
+            }
+            expected := tt.first
+            for _, week := range grid {
+                if len(week) != 7 || week[0].Weekday() != tt.weekStart {
+                    t.Fatalf("MonthGrid() week %v, want 7 days starting on %v", week, tt.weekStart)
+                }
+                for _, day := range week {
+                    if !day.Equal(expected) {
+                        t.Fatalf("MonthGrid() day = %v, want %v", day, expected)
+                    }
+                    expected = expected.AddDate(0, 0, 1)
+                }
+            }
+            if last := grid[5][6]; !last.Equal(tt.last) {
+                t.Errorf("MonthGrid() last day = %v, want %v", last, tt.last)
+            }
+        })
+    }
+
+    // 2023-11-05 为 America/New_York 的夏令时结束日，当天为 25 小时，网格中仍应为每天的零点
+    location, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        t.Skip(err)
+    }
+    for _, week := range chrono.MonthGrid(time.Date(2023, 11, 15, 0, 0, 0, 0, location), time.Sunday) {
+        for _, day := range week {
+            if day.Hour() != 0 || day.Minute() != 0 || day.Location() != location {
+                t.Errorf("MonthGrid() in %v contains %v, want midnight", location, day)
+            }
+        }
+    }
+}
+
+func TestStartOfDST(t *testing.T) {
+    // 2018-11-04 America/Sao_Paulo 的夏令时于午夜开始，当天的 00:00 并不存在
+    location, err := time.LoadLocation("America/Sao_Paulo")
+    if err != nil {
+        t.Skip(err)
+    }
+    now := time.Date(2018, 11, 4, 12, 0, 0, 0, location)
+    expected := time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC)
+
+    for _, unit := range []chrono.Unit{chrono.UnitDay, chrono.UnitSunday} {
+        result := chrono.StartOf(now, unit)
+        if !result.Equal(expected) || result.Day() != 4 || result.Hour() != 1 {
+            t.Errorf("StartOf(%d) = %v, want %v", unit, result, expected.In(location))
+        }
+    }
+
+    if result := chrono.NextStartOf(now.AddDate(0, 0, -1), chrono.UnitDay); !result.Equal(expected) {
+        t.Errorf("NextStartOf() = %v, want %v", result, expected.In(location))
+    }
+}
+
+func TestUntilMoment(t *testing.T) {
+    tests := []struct {
+        name     string
+        now      time.Time
+        expected time.Duration
+    }{
+        {
+            name:     "Before target moment",
+            now:      time.Date(2023, 10, 1, 12, 0, 0, 0, time.Local),
+            expected: 3 * time.Hour,
+        },
+        {
+            name:     "Exactly at target moment",
+            now:      time.Date(2023, 10, 1, 15, 0, 0, 0, time.Local),
+            expected: 0,
+        },
+        {
+            name:     "Just passed",
+            now:      time.Date(2023, 10, 1, 15, 0, 1, 0, time.Local),
+            expected: 24*time.Hour - time.Second,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if result := chrono.UntilMoment(tt.now, 15, 0, 0); result != tt.expected {
+                t.Errorf("UntilMoment() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+}
+
+func TestEachMonth(t *testing.T) {
+    start := time.Date(2023, 10, 31, 12, 0, 0, 0, time.Local)
+    end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)
+
+    var months []time.Time
+    chrono.EachMonth(start, end, func(monthStart time.Time) bool {
+        months = append(months, monthStart)
+        return true
+    })
+
+    expected := []time.Time{
+        time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local),
+        time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local),
+        time.Date(2023, 12, 1, 0, 0, 0, 0, time.Local),
+        time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+        time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local),
+    }
+    if len(months) != len(expected) {
+        t.Fatalf("EachMonth() = %v, want %v", months, expected)
+    }
+    for i := range months {
+        if !months[i].Equal(expected[i]) {
+            t.Errorf("EachMonth()[%d] = %v, want %v", i, months[i], expected[i])
+        }
+    }
+
+    var count int
+    chrono.EachMonth(start, end, func(monthStart time.Time) bool {
+        count++
+        return count < 2
+    })
+    if count != 2 {
+        t.Errorf("EachMonth() called fn %d times after stop, want 2", count)
+    }
+}
+
+func TestMomentLocation(t *testing.T) {
+    location := time.FixedZone("UTC+8", 8*60*60)
+    for _, now := range []time.Time{
+        time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC),
+        time.Date(2023, 10, 1, 16, 0, 0, 0, time.UTC),
+        time.Date(2023, 10, 1, 12, 0, 0, 0, location),
+    } {
+        next := chrono.NextMoment(now, 15, 0, 0)
+        if next.Location() != now.Location() || next.Hour() != 15 {
+            t.Errorf("NextMoment(%v) = %v, want 15:00 in %v", now, next, now.Location())
+        }
+        elapsed := chrono.Elapsed(now, 15, 0, 0)
+        if future := chrono.Future(now, 15, 0, 0); future == elapsed {
+            t.Errorf("Future(%v) = %v, Elapsed() = %v, want opposite", now, future, elapsed)
+        }
+        if sameDay := next.Day() == now.Day(); sameDay == elapsed {
+            t.Errorf("NextMoment(%v) = %v disagrees with Elapsed() = %v", now, next, elapsed)
+        }
+    }
+}
+
+func TestMomentD(t *testing.T) {
+    now := time.Date(2023, 10, 1, 9, 30, 0, 500, time.UTC)
+    tests := []struct {
+        name    string
+        offset  time.Duration
+        next    time.Time
+        elapsed bool
+    }{
+        {
+            name:    "Before target moment",
+            offset:  9*time.Hour + 30*time.Minute + 1000,
+            next:    time.Date(2023, 10, 1, 9, 30, 0, 1000, time.UTC),
+            elapsed: false,
+        },
+        {
+            name:    "At target moment",
+            offset:  9*time.Hour + 30*time.Minute + 500,
+            next:    time.Date(2023, 10, 2, 9, 30, 0, 500, time.UTC),
+            elapsed: false,
+        },
+        {
+            name:    "After target moment",
+            offset:  9*time.Hour + 30*time.Minute,
+            next:    time.Date(2023, 10, 2, 9, 30, 0, 0, time.UTC),
+            elapsed: true,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := chrono.NextMomentD(now, tt.offset); !got.Equal(tt.next) {
+                t.Errorf("NextMomentD() = %v, want %v", got, tt.next)
+            }
+            if got := chrono.ElapsedD(now, tt.offset); got != tt.elapsed {
+                t.Errorf("ElapsedD() = %v, want %v", got, tt.elapsed)
+            }
+            if got := chrono.FutureD(now, tt.offset); got == tt.elapsed {
+                t.Errorf("FutureD() = %v, want %v", got, !tt.elapsed)
+            }
+        })
+    }
+}
+
+func TestMomentDOutOfRange(t *testing.T) {
+    for _, offset := range []time.Duration{-1, 24 * time.Hour} {
+        func() {
+            defer func() {
+                if err, ok := recover().(error); !ok || !errors.Is(err, chrono.ErrOffsetOutOfRange) {
+                    t.Errorf("NextMomentD(%v) recovered %v, want ErrOffsetOutOfRange", offset, err)
+                }
+            }()
+            chrono.NextMomentD(time.Now(), offset)
+        }()
+    }
+}
+
+func TestCalendarDiff(t *testing.T) {
+    tests := []struct {
+        name                string
+        start, end          time.Time
+        years, months, days int
+        rest                time.Duration
+    }{
+        {
+            name:   "Years and months",
+            start:  time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC),
+            end:    time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC),
+            years:  1,
+            months: 2,
+        },
+        {
+            name:   "Day not yet reached",
+            start:  time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC),
+            end:    time.Date(2023, 3, 15, 9, 0, 0, 0, time.UTC),
+            months: 1,
+            days:   27,
+            rest:   23 * time.Hour,
+        },
+        {
+            name:   "Clamped month end",
+            start:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+            end:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+            months: 1,
+        },
+        {
+            name:   "Reversed",
+            start:  time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC),
+            end:    time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC),
+            years:  -1,
+            months: -2,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            years, months, days, rest := chrono.CalendarDiff(tt.start, tt.end)
+            if years != tt.years || months != tt.months || days != tt.days || rest != tt.rest {
+                t.Errorf("CalendarDiff() = %d, %d, %d, %v, want %d, %d, %d, %v",
+                    years, months, days, rest, tt.years, tt.months, tt.days, tt.rest)
+            }
+        })
+    }
+}
+
+func TestNextMomentJitter(t *testing.T) {
+    now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+    moment := chrono.NextMoment(now, 23, 59, 0)
+    for i := 0; i < 100; i++ {
+        next := chrono.NextMomentJitter(now, 23, 59, 0, 2*time.Minute)
+        if next.Before(moment) || !next.Before(moment.Add(2*time.Minute)) {
+            t.Fatalf("NextMomentJitter() = %v, want within [%v, %v)", next, moment, moment.Add(2*time.Minute))
+        }
+    }
+    if next := chrono.NextMomentJitter(now, 23, 59, 0, 0); !next.Equal(moment) {
+        t.Errorf("NextMomentJitter() with zero jitter = %v, want %v", next, moment)
+    }
+}
+
+func TestCountWeekday(t *testing.T) {
+    location, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        t.Skip(err)
+    }
+    start := time.Date(2024, 1, 15, 18, 30, 0, 0, location)
+
+    for _, end := range []time.Time{
+        time.Date(2024, 1, 15, 6, 0, 0, 0, location),
+        time.Date(2024, 1, 20, 23, 0, 0, 0, location),
+        time.Date(2024, 5, 31, 0, 0, 0, 0, location),
+        time.Date(2025, 2, 3, 12, 0, 0, 0, time.UTC),
+        time.Date(2023, 12, 31, 0, 0, 0, 0, location),
+    } {
+        for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+            var expected int
+            for day := chrono.StartOf(start, chrono.UnitDay); !day.After(end); day = day.AddDate(0, 0, 1) {
+                if day.Weekday() == weekday {
+                    expected++
+                }
+            }
+            if result := chrono.CountWeekday(start, end, weekday); result != expected {
+                t.Errorf("CountWeekday(%v, %v, %v) = %d, want %d", start, end, weekday, result, expected)
+            }
+        }
+    }
+
+    // 2024 年 5 月共有 5 个周五
+    may := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+    if result := chrono.CountWeekday(may, chrono.EndOf(may, chrono.UnitMonth), time.Friday); result != 5 {
+        t.Errorf("CountWeekday() in May 2024 = %d, want 5", result)
+    }
+}
+
+func TestToWeekday(t *testing.T) {
+    location, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        t.Skip(err)
+    }
+    // 2024 年 3 月 10 日（周日）为夏令时开始日，调整后应保留墙上时间
+    at := func(day int) time.Time {
+        return time.Date(2024, 3, day, 9, 30, 0, 0, location)
+    }
+
+    var tests = []struct {
+        name     string
+        fn       func(time.Time) time.Time
+        input    time.Time
+        expected time.Time
+    }{
+        {"NearestSaturday", chrono.ToNearestWeekday, at(9), at(8)},
+        {"NearestSunday", chrono.ToNearestWeekday, at(10), at(11)},
+        {"NearestWeekday", chrono.ToNearestWeekday, at(13), at(13)},
+        {"NextSaturday", chrono.ToNextWeekday, at(9), at(11)},
+        {"NextSunday", chrono.ToNextWeekday, at(10), at(11)},
+        {"NextWeekday", chrono.ToNextWeekday, at(8), at(8)},
+        {"PrevSaturday", chrono.ToPrevWeekday, at(9), at(8)},
+        {"PrevSunday", chrono.ToPrevWeekday, at(10), at(8)},
+        {"PrevWeekday", chrono.ToPrevWeekday, at(11), at(11)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := tt.fn(tt.input)
+            if !result.Equal(tt.expected) || result.Location() != location {
+                t.Errorf("%s(%v) = %v, want %v", tt.name, tt.input, result, tt.expected)
+            }
+            if chrono.IsWeekend(result) {
+                t.Errorf("%s(%v) = %v, want a weekday", tt.name, tt.input, result)
+            }
+        })
+    }
+}
+
+func TestQuarter(t *testing.T) {
+    tests := []struct {
+        name        string
+        t           time.Time
+        fiscalStart time.Month
+        quarter     int
+        fiscalYear  int
+        fiscal      int
+    }{
+        {
+            name:        "July fiscal year in March",
+            t:           time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local),
+            fiscalStart: time.July,
+            quarter:     1,
+            fiscalYear:  2023,
+            fiscal:      3,
+        },
+        {
+            name:        "July fiscal year in July",
+            t:           time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local),
+            fiscalStart: time.July,
+            quarter:     3,
+            fiscalYear:  2024,
+            fiscal:      1,
+        },
+        {
+            name:        "April fiscal year in December",
+            t:           time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local),
+            fiscalStart: time.April,
+            quarter:     4,
+            fiscalYear:  2024,
+            fiscal:      3,
+        },
+        {
+            name:        "Calendar year",
+            t:           time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local),
+            fiscalStart: time.January,
+            quarter:     2,
+            fiscalYear:  2024,
+            fiscal:      2,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if quarter := chrono.Quarter(tt.t); quarter != tt.quarter {
+                t.Errorf("Quarter() = %d, want %d", quarter, tt.quarter)
+            }
+            if year, quarter := chrono.FiscalQuarter(tt.t, tt.fiscalStart); year != tt.fiscalYear || quarter != tt.fiscal {
+                t.Errorf("FiscalQuarter() = %d, %d, want %d, %d", year, quarter, tt.fiscalYear, tt.fiscal)
+            }
+        })
+    }
+}
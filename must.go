@@ -0,0 +1,36 @@
+package chrono
+
+import "time"
+
+// MustParsePeriod 与 ParsePeriod 相同，但在解析失败时抛出异常。
+//
+// 适用于测试数据及包级变量的初始化等难以处理错误的场景。
+func MustParsePeriod(s string) Period {
+    p, err := ParsePeriod(s)
+    if err != nil {
+        panic(err)
+    }
+    return p
+}
+
+// MustParseDuration 与 ParseDuration 相同，但在解析失败时抛出异常。
+//
+// 适用于测试数据及包级变量的初始化等难以处理错误的场景。
+func MustParseDuration(s string) time.Duration {
+    d, err := ParseDuration(s)
+    if err != nil {
+        panic(err)
+    }
+    return d
+}
+
+// MustLoadLocation 与 time.LoadLocation 相同，但在加载失败时抛出异常。
+//
+// 适用于测试数据及包级变量的初始化等难以处理错误的场景。
+func MustLoadLocation(name string) *time.Location {
+    location, err := time.LoadLocation(name)
+    if err != nil {
+        panic(err)
+    }
+    return location
+}
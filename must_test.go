@@ -0,0 +1,46 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+// mustPanic 断言 f 发生 panic
+func mustPanic(t *testing.T, name string, f func()) {
+    t.Helper()
+    defer func() {
+        if recover() == nil {
+            t.Errorf("%s did not panic", name)
+        }
+    }()
+    f()
+}
+
+func TestMustParsePeriod(t *testing.T) {
+    p := chrono.MustParsePeriod("2023-10-01T00:00:00Z/2023-10-02T00:00:00Z")
+    if !p.Start().Equal(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)) || !p.End().Equal(time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)) {
+        t.Errorf("MustParsePeriod() = %v", p)
+    }
+    mustPanic(t, "MustParsePeriod(invalid)", func() {
+        chrono.MustParsePeriod("2023-10-01T00:00:00Z")
+    })
+}
+
+func TestMustParseDuration(t *testing.T) {
+    if d := chrono.MustParseDuration("1d12h"); d != 36*time.Hour {
+        t.Errorf("MustParseDuration() = %v, want %v", d, 36*time.Hour)
+    }
+    mustPanic(t, "MustParseDuration(invalid)", func() {
+        chrono.MustParseDuration("1d-2h")
+    })
+}
+
+func TestMustLoadLocation(t *testing.T) {
+    if location := chrono.MustLoadLocation("UTC"); location.String() != "UTC" {
+        t.Errorf("MustLoadLocation() = %v, want UTC", location)
+    }
+    mustPanic(t, "MustLoadLocation(invalid)", func() {
+        chrono.MustLoadLocation("Invalid/Location")
+    })
+}
@@ -1,6 +1,11 @@
 package chrono
 
 import (
+    "fmt"
+    "math"
+    "sort"
+    "strconv"
+    "strings"
     "time"
 )
 
@@ -21,6 +26,30 @@ func NewPeriod(start, end time.Time) Period {
     return Period{start, end}
 }
 
+// ParsePeriod 解析以 "开始时间/结束时间" 表示的时间段字符串。
+//
+// 参数 s 的格式参考 ISO 8601 时间间隔的表示方式，开始时间与结束时间均需符合 time.RFC3339Nano 格式，
+// 例如 "2023-10-01T00:00:00Z/2023-10-02T00:00:00+08:00"。
+//
+// 关键行为说明：
+//  - 与 NewPeriod 一致，开始时间晚于结束时间时两者将被交换
+//  - 格式不正确时返回错误
+func ParsePeriod(s string) (Period, error) {
+    start, end, ok := strings.Cut(s, "/")
+    if !ok {
+        return Period{}, fmt.Errorf("chrono: invalid period %q: missing separator", s)
+    }
+    st, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(start))
+    if err != nil {
+        return Period{}, fmt.Errorf("chrono: invalid period %q: %w", s, err)
+    }
+    et, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(end))
+    if err != nil {
+        return Period{}, fmt.Errorf("chrono: invalid period %q: %w", s, err)
+    }
+    return NewPeriod(st, et), nil
+}
+
 // Period 表示一个时间区间，由开始时间和结束时间组成。
 //
 // 时间区间的开始和结束时间通过两个 time.Time 类型的值表示。
@@ -31,6 +60,7 @@ func NewPeriod(start, end time.Time) Period {
 //  - 时间区间的持续时间可通过 Duration 方法获取
 //  - 可通过 IsZero 判断是否为零值
 //  - 通过 IsInvalid 判断是否包含无效的时间
+//  - 通过字面量 Period{end, start} 构造时将绕过 NewPeriod 的顺序调整，此时 Duration、Days、Hours 等方法将返回负值，应先调用 Normalize
 //  - 提供多种方法来判断时间段与指定时间的关系，如 IsBefore, IsAfter, IsBetween 等
 //
 // 使用建议：
@@ -40,6 +70,13 @@ func NewPeriod(start, end time.Time) Period {
 // 并发机制方面，由于是简单的数据结构，通常不需要特别的并发控制。
 type Period [2]time.Time
 
+// Normalize 返回开始时间不晚于结束时间的时间段，当开始时间晚于结束时间时两者将被交换。
+//
+// 通过 NewPeriod 创建的时间段已经是规范化的，该方法主要用于修正通过字面量 Period{end, start} 等方式绕过 NewPeriod 构造的时间段。
+func (p Period) Normalize() Period {
+    return NewPeriod(p[0], p[1])
+}
+
 // Start 返回时间段的开始时间。
 //
 // 该方法直接返回 Period 结构体中的第一个 time.Time 值，表示时间段的起始点。
@@ -80,6 +117,7 @@ func (p Period) End() time.Time {
 // 关键行为说明：
 //  - 调用此方法不会改变 Period 的内部状态
 //  - 若需要获取更细粒度的时间单位，请使用其他相关方法如 Days, Hours 等
+//  - 对于通过字面量构造且开始时间晚于结束时间的时间段，将返回负值，基于该方法的 Days、Hours 等方法同样如此，可通过 Normalize 进行修正
 //
 // 使用建议：
 //  - 确保 Period 实例有效且非零值，以避免返回无效的时间
@@ -88,18 +126,157 @@ func (p Period) Duration() time.Duration {
     return p[1].Sub(p[0])
 }
 
+// HumanDuration 返回时间段持续时间的日历化描述，例如 "1 year 2 months 3 days"。
+//
+// 与以固定时长表示的 Duration 不同，该方法通过 CalendarDiff 按照开始时间所在时区的日历计算年、月、日，
+// 适用于面向用户展示较长的时间跨度，避免出现 "426 days" 或巨大的小时数。
+//
+// 关键行为说明：
+//  - 仅输出非零的部分，例如恰好 14 个月的时间段将返回 "1 year 2 months"
+//  - 当时间段跨越至少一天时，不足一天的部分将被截断
+//  - 当时间段不足一天时，将以小时、分钟、秒进行描述，不足一秒时返回 "0 seconds"
+func (p Period) HumanDuration() string {
+    type part struct {
+        value int
+        name  string
+    }
+
+    years, months, days, rest := CalendarDiff(p[0], p[1])
+    parts := []part{
+        {years, "year"},
+        {months, "month"},
+        {days, "day"},
+    }
+    if years == 0 && months == 0 && days == 0 {
+        parts = []part{
+            {int(rest / time.Hour), "hour"},
+            {int(rest % time.Hour / time.Minute), "minute"},
+            {int(rest % time.Minute / time.Second), "second"},
+        }
+    }
+
+    var builder strings.Builder
+    for _, part := range parts {
+        if part.value == 0 {
+            continue
+        }
+        if builder.Len() > 0 {
+            builder.WriteByte(' ')
+        }
+        builder.WriteString(strconv.Itoa(part.value))
+        builder.WriteByte(' ')
+        builder.WriteString(part.name)
+        if part.value != 1 {
+            builder.WriteByte('s')
+        }
+    }
+    if builder.Len() == 0 {
+        return "0 seconds"
+    }
+    return builder.String()
+}
+
 // Days 返回时间段的持续天数。
 //
-// 该方法通过计算时间段的总小时数并转换为天数来返回结果。
-// 如果时间段小于一天，结果将被截断为整数天数。
+// 该方法通过计算时间段的总小时数并转换为天数来返回结果，即以 24 小时作为一天，统计时间段中包含的完整天数。
+// 如果时间段小于一天，结果将被截断为整数天数，例如 47 小时的时间段将返回 1。
 //
 // 关键行为说明：
 //  - 时间段的持续时间由 Duration 方法计算
 //  - 结果为整数天数，小数部分会被截断
+//  - 如需向上取整或四舍五入，请使用 DaysCeil 或 DaysRound，如需统计跨越的日历天数，请使用 CalendarDays
 func (p Period) Days() int {
     return int(p.Duration().Hours() / 24)
 }
 
+// DaysCeil 返回时间段的持续天数，不足一天的部分将向上取整。
+//
+// 与 Days 一致，该方法以 24 小时作为一天，例如 47 小时的时间段将返回 2，恰好 48 小时的时间段同样返回 2。
+func (p Period) DaysCeil() int {
+    return int(math.Ceil(p.Duration().Hours() / 24))
+}
+
+// DaysRound 返回时间段的持续天数，不足一天的部分将四舍五入。
+//
+// 与 Days 一致，该方法以 24 小时作为一天，例如 36 小时的时间段将返回 2，35 小时的时间段将返回 1。
+func (p Period) DaysRound() int {
+    return int(math.Round(p.Duration().Hours() / 24))
+}
+
+// CalendarDays 返回时间段所涉及的不同日历日期的数量。
+//
+// 与基于时长的 Days 不同，该方法统计的是时间段跨越的日历天数，例如从 23:59 至次日 00:01 的时间段将返回 2。
+// 日期基于开始时间所在的时区进行计算。
+//
+// 关键行为说明：
+//  - 结束时间被视为开区间，因此从某日零点至次日零点的时间段将返回 1
+//  - 开始时间与结束时间相同时返回 1
+//  - 不受夏令时导致的单日时长变化影响
+func (p Period) CalendarDays() int {
+    end := p[1].In(p[0].Location())
+    if end.After(p[0]) {
+        end = end.Add(-time.Nanosecond)
+    }
+    sy, sm, sd := p[0].Date()
+    ey, em, ed := end.Date()
+    start := time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC)
+    return int(time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC).Sub(start)/Day) + 1
+}
+
+// Months 返回时间段按照日历计算的完整月数，例如从 1 月 15 日至 3 月 10 日的时间段将返回 1。
+//
+// 与以固定时长计算的 Days 不同，该方法基于 CalendarDiff 计算，因此不受月份天数差异的影响。
+//
+// 关键行为说明：
+//  - 返回值包含完整的年份，例如 14 个月的时间段将返回 14 而非 2
+//  - 开始时间为月末时，将以目标月份的最后一天作为对齐点，例如 1 月 31 日至闰年的 2 月 29 日视为 1 个月
+//  - 日期基于开始时间所在的时区进行计算
+func (p Period) Months() int {
+    years, months, _, _ := CalendarDiff(p.Start(), p.End())
+    return years*12 + months
+}
+
+// Years 返回时间段按照日历计算的完整年数，例如从闰年的 2 月 29 日至次年的 2 月 28 日将返回 1，详见 Months。
+func (p Period) Years() int {
+    years, _, _, _ := CalendarDiff(p.Start(), p.End())
+    return years
+}
+
+// EachStep 从开始时间起以固定的 step 依次遍历时间段内的时间点，并以此调用 fn，当 fn 返回 false 时将提前终止遍历。
+//
+// 关键行为说明：
+//  - 遍历包含开始时间，当结束时间恰好位于步进点上时同样包含结束时间
+//  - step 为固定时长，跨越夏令时切换时墙上时间将发生偏移，如需按日历日期遍历请使用 EachDate
+//  - 当 step 小于等于 0 时不进行任何遍历
+func (p Period) EachStep(step time.Duration, fn func(t time.Time) bool) {
+    if step <= 0 {
+        return
+    }
+    for t := p.Start(); !t.After(p.End()); t = t.Add(step) {
+        if !fn(t) {
+            return
+        }
+    }
+}
+
+// EachDate 依次遍历时间段所涉及的每个日历日期的零点，并以此调用 fn，当 fn 返回 false 时将提前终止遍历。
+//
+// 遍历从开始时间所在日期的零点开始，到结束时间所在日期的零点结束（包含），适用于日历渲染等按天展示的场景。
+//
+// 关键行为说明：
+//  - 日期按照日历推进而非固定的 24 小时，因此在夏令时切换导致一天为 23 或 25 小时时，每次遍历的时间仍为当天的零点
+//  - 零点因夏令时切换而不存在时，将使用当天第一个有效的时刻，与 StartOf 的行为一致
+//  - 日期基于开始时间所在的时区进行计算
+//  - 与 CalendarDays 不同，结束时间恰好为零点时，该日期同样会被遍历
+func (p Period) EachDate(fn func(date time.Time) bool) {
+    last := StartOf(p.End().In(p.Start().Location()), UnitDay)
+    for date := StartOf(p.Start(), UnitDay); !date.After(last); date = NextStartOf(date, UnitDay) {
+        if !fn(date) {
+            return
+        }
+    }
+}
+
 // Hours 返回时间段的持续小时数。
 //
 // 该方法通过计算时间段的总秒数并转换为小时数来返回结果。
@@ -181,28 +358,44 @@ func (p Period) IsInvalid() bool {
     return p[0].IsZero() || p[1].IsZero()
 }
 
-// Before 检查给定时间是否在当前周期之后。
+// EndsBefore 判断周期的结束时间是否早于 t，即周期是否完全位于 t 之前。
+//
+// 关键行为说明：
+//  - 当 t 等于结束时间时返回 false
 //
-// 参数 t 为要比较的时间点。方法通过内部存储的结束时间与 t 进行比较。
-// 如果 t 在结束时间之前，则返回 true，表示 t 在当前周期内。
-// 反之则返回 false，表明 t 已超出当前周期范围。
+// 使用建议：
+// 判断周期相对于当前时间是否已经过去时，优先使用语义更明确的 IsPast。
+func (p Period) EndsBefore(t time.Time) bool {
+    return p[1].Before(t)
+}
+
+// StartsAfter 判断周期的起始时间是否晚于 t，即周期是否完全位于 t 之后。
 //
 // 关键行为说明：
-//  - 当 t 等于结束时间时，同样视为不在周期内
+//  - 当 t 等于起始时间时返回 false
 //
 // 使用建议：
-// 用于判断事件发生时间是否属于特定周期内，便于时间序列分析和处理。
+// 判断周期相对于当前时间是否尚未开始时，优先使用语义更明确的 IsFuture。
+func (p Period) StartsAfter(t time.Time) bool {
+    return p[0].After(t)
+}
+
+// Before 判断周期的结束时间是否早于 t，即周期是否完全位于 t 之前，与 EndsBefore 等价。
+//
+// 注意，该方法并非判断周期的起始时间是否早于 t，其名称容易引起误解，因此保留仅为兼容。
+//
+// Deprecated: 请使用语义明确的 EndsBefore 或 IsPast。
 func (p Period) Before(t time.Time) bool {
-    return p[1].Before(t)
+    return p.EndsBefore(t)
 }
 
-// After 检查给定时间是否在当前时间段之后。
+// After 判断周期的起始时间是否晚于 t，即周期是否完全位于 t 之后，与 StartsAfter 等价。
+//
+// 注意，该方法并非判断周期的结束时间是否晚于 t，其名称容易引起误解，因此保留仅为兼容。
 //
-// 该方法接收一个 time.Time 类型的参数 t，用于比较是否在时间段 p 之后。
-// 如果 t 在 p 之后，则返回 true；否则返回 false。
-// 注意，p[0] 表示时间段的起始时间。
+// Deprecated: 请使用语义明确的 StartsAfter 或 IsFuture。
 func (p Period) After(t time.Time) bool {
-    return p[0].After(t)
+    return p.StartsAfter(t)
 }
 
 // Between 判断给定时间是否在周期内。
@@ -220,6 +413,32 @@ func (p Period) Between(t time.Time) bool {
     return (p[0].Before(t) || p[0].Equal(t)) && (p[1].After(t) || p[1].Equal(t))
 }
 
+// IsPast 判断周期相对于 now 是否已经完全过去，即周期的结束时间早于 now。
+//
+// 该方法与 EndsBefore(now) 等价，但以周期本身为主语表达了 "已结束" 的意图，适用于对事件列表进行分类筛选。
+//
+// 关键行为说明：
+//  - 当 now 等于结束时间时，周期仍视为进行中，此时 IsCurrent 返回 true
+//  - IsPast、IsFuture 与 IsCurrent 对于同一个 now 有且仅有一个返回 true
+func (p Period) IsPast(now time.Time) bool {
+    return p.EndsBefore(now)
+}
+
+// IsFuture 判断周期相对于 now 是否尚未开始，即周期的起始时间晚于 now。
+//
+// 该方法与 StartsAfter(now) 等价，但以周期本身为主语表达了 "未开始" 的意图。
+// 当 now 等于起始时间时，周期视为进行中。
+func (p Period) IsFuture(now time.Time) bool {
+    return p.StartsAfter(now)
+}
+
+// IsCurrent 判断周期相对于 now 是否正在进行中，即 now 位于周期的起始时间（含）与结束时间（含）之间。
+//
+// 该方法与 Between(now) 等价，与 IsPast、IsFuture 共同构成了周期相对于 now 的完整分类。
+func (p Period) IsCurrent(now time.Time) bool {
+    return p.Between(now)
+}
+
 // BetweenOrEqual 检查当前周期是否与给定周期重叠或相等。
 //
 // 该方法通过比较两个周期的起始和结束时间点来判断是否存在重叠或完全相同的情况。
@@ -247,3 +466,311 @@ func (p Period) BetweenOrEqual(t Period) bool {
 func (p Period) Overlap(t Period) bool {
     return p.BetweenOrEqual(t) || t.BetweenOrEqual(p)
 }
+
+// OverlapsWith 按照指定的边界语义检查两个时间段是否存在重叠，即是否存在同时位于两个时间段内的时刻。
+//
+// 参数 startInclusive 与 endInclusive 分别指定了时间段的开始时间与结束时间是否属于时间段，并同时作用于 p 与 other。
+// 例如预约、排班等场景通常使用左闭右开区间，此时前一个时间段的结束时间与后一个时间段的开始时间相接并不视为重叠。
+//
+// 关键行为说明：
+//  - OverlapsWith(other, true, true) 与 Overlap 的结果一致，OverlapsWith(other, true, false) 即为左闭右开区间的重叠判断
+//  - 两个时间段的内部存在交集时，无论边界语义如何均视为重叠
+//  - 边界语义仅影响两个时间段相接于一点的情况，以及开始时间与结束时间相同的时间段，后者在任一边界为开区间时不包含任何时刻
+func (p Period) OverlapsWith(other Period, startInclusive, endInclusive bool) bool {
+    lo, hi := Max(p.Start(), other.Start()), Min(p.End(), other.End())
+    if lo.Before(hi) {
+        return true
+    }
+    if !lo.Equal(hi) {
+        return false
+    }
+    // 交集仅为一个时刻，需要该时刻同时属于两个时间段
+    contains := func(period Period) bool {
+        return (startInclusive || !lo.Equal(period.Start())) && (endInclusive || !lo.Equal(period.End()))
+    }
+    return contains(p) && contains(other)
+}
+
+// Equal 判断两个时间段的开始时间与结束时间是否分别表示相同的时刻。
+//
+// 由于 Period 是数组类型，可以直接使用 == 进行比较，但 == 会同时比较 time.Time 中的时区及单调时钟读数，
+// 可能导致表示相同时刻的时间段被判断为不相等。因此在判断时间段是否相等时，应当优先使用该方法。
+func (p Period) Equal(other Period) bool {
+    return p[0].Equal(other[0]) && p[1].Equal(other[1])
+}
+
+// Compare 比较两个时间段的先后顺序，优先比较开始时间，开始时间相同时比较结束时间。
+//
+// 当 p 早于 other 时返回 -1，晚于 other 时返回 +1，相同时返回 0，其语义与 time.Time.Compare 一致，适用于排序等场景。
+func (p Period) Compare(other Period) int {
+    if c := p[0].Compare(other[0]); c != 0 {
+        return c
+    }
+    return p[1].Compare(other[1])
+}
+
+// Percent 返回时间点 t 在时间段中所处的进度，取值范围为 0.0 至 1.0。
+//
+// 当 t 不晚于开始时间时返回 0.0，不早于结束时间时返回 1.0，位于两者之间时返回基于 Duration 的线性比例。
+//
+// 关键行为说明：
+//  - 对于持续时间为零的时间段，当 t 不早于开始时间时返回 1.0，否则返回 0.0
+//
+// 使用建议：
+// 适用于进度条、倒计时等需要展示时间进度的场景。
+func (p Period) Percent(t time.Time) float64 {
+    if t.Before(p[0]) {
+        return 0
+    }
+    if !t.Before(p[1]) {
+        return 1
+    }
+    return float64(t.Sub(p[0])) / float64(p.Duration())
+}
+
+// PercentNow 返回当前时间在时间段中所处的进度，等同于 p.Percent(time.Now())。
+func (p Period) PercentNow() float64 {
+    return p.Percent(time.Now())
+}
+
+// In 返回将开始时间与结束时间均转换至 loc 时区后的新时间段，适用于将以 UTC 存储的时间段按用户所在的时区展示等场景。
+//
+// 转换仅改变时间的展示方式，时间段所表示的时刻、持续时间及与其他时间段的关系均保持不变。
+//
+// 关键行为说明：
+//  - 与 time.Time.In 一致，当 loc 为 nil 时将抛出异常
+func (p Period) In(loc *time.Location) Period {
+    return Period{p[0].In(loc), p[1].In(loc)}
+}
+
+// Shift 返回将时间段的开始时间与结束时间同时平移 d 后的新时间段。
+//
+// 参数 d 为平移的时长，正值向后平移，负值向前平移。平移不会改变时间段的持续时间。
+//
+// 使用建议：
+// 适用于 "上周同一时间段" 等需要按固定时长平移时间窗口的场景，如需按日历平移请使用 ShiftDate。
+func (p Period) Shift(d time.Duration) Period {
+    return NewPeriod(p[0].Add(d), p[1].Add(d))
+}
+
+// ShiftDate 返回将时间段的开始时间与结束时间同时按日历平移后的新时间段。
+//
+// 参数 years、months、days 的含义与 time.Time.AddDate 一致，平移将分别作用于开始时间和结束时间。
+//
+// 关键行为说明：
+//  - 平移基于日历计算，跨越月份或夏令时切换时，时间段的持续时间可能发生变化
+//  - 与 time.Time.AddDate 一致，溢出的日期会被规范化，例如 10 月 31 日平移一个月将得到 12 月 1 日
+func (p Period) ShiftDate(years, months, days int) Period {
+    return NewPeriod(p[0].AddDate(years, months, days), p[1].AddDate(years, months, days))
+}
+
+// Extend 返回在时间段两端分别扩展后的新时间段。
+//
+// 参数 before 表示开始时间向前扩展的时长，after 表示结束时间向后扩展的时长，负值表示收缩。
+//
+// 关键行为说明：
+//  - 当收缩幅度过大导致开始时间晚于结束时间时，两者将被交换以保证时间段的有效性
+func (p Period) Extend(before, after time.Duration) Period {
+    return NewPeriod(p[0].Add(-before), p[1].Add(after))
+}
+
+// Midpoint 返回时间段的中点，即开始时间加上持续时间的一半，适用于在时间范围的中央放置标签等场景。
+//
+// 长度为零的时间段的中点即为其开始时间。
+func (p Period) Midpoint() time.Time {
+    return p[0].Add(p.Duration() / 2)
+}
+
+// Quartiles 返回时间段中位于 25%、50% 与 75% 处的时刻，适用于绘制坐标轴刻度等场景。
+//
+// 其中第二个元素与 Midpoint 相同，长度为零的时间段的所有时刻均等于其开始时间。
+func (p Period) Quartiles() [3]time.Time {
+    d := p.Duration()
+    // 分别计算商与余数的 3/4，避免 d / 4 * 3 的截断误差及 d * 3 / 4 的溢出
+    q, r := d/4, d%4
+    return [3]time.Time{p[0].Add(q), p[0].Add(d / 2), p[0].Add(3*q + 3*r/4)}
+}
+
+// WithStart 返回将开始时间替换为 t 后的新时间段，原时间段保持不变。
+//
+// 关键行为说明：
+//  - 与 NewPeriod 一致，当 t 晚于原结束时间时两者将被交换，此时原结束时间将成为新时间段的开始时间
+func (p Period) WithStart(t time.Time) Period {
+    return NewPeriod(t, p[1])
+}
+
+// WithEnd 返回将结束时间替换为 t 后的新时间段，原时间段保持不变，适用于 "延长预订的结束时间" 等场景。
+//
+// 关键行为说明：
+//  - 与 NewPeriod 一致，当 t 早于原开始时间时两者将被交换，此时原开始时间将成为新时间段的结束时间
+func (p Period) WithEnd(t time.Time) Period {
+    return NewPeriod(p[0], t)
+}
+
+// Cap 返回开始时间不变、持续时间不超过 max 的新时间段，即结束时间不晚于 start + max，等同于 CapEnd(p.Start().Add(max))。
+//
+// 适用于展示开放式时间段等场景，例如将 "自 X 起持续至今" 的时间段截断为合理的长度后进行渲染。
+//
+// 关键行为说明：
+//  - 当持续时间不超过 max 时，将原样返回时间段
+//  - 当 max 小于等于 0 时，将返回长度为零的时间段
+func (p Period) Cap(max time.Duration) Period {
+    return p.CapEnd(p[0].Add(max))
+}
+
+// CapEnd 返回开始时间不变、结束时间不晚于 latest 的新时间段，例如 CapEnd(time.Now().Add(buffer))。
+//
+// 关键行为说明：
+//  - 当结束时间不晚于 latest 时，将原样返回时间段
+//  - 与 WithEnd 不同，当 latest 早于开始时间时不会交换两者，而是返回长度为零的时间段，以保证开始时间不变
+func (p Period) CapEnd(latest time.Time) Period {
+    return Period{p[0], Max(p[0], Min(p[1], latest))}
+}
+
+// OverlapGroups 将一组时间段按照时间上的连通关系进行分组，返回每组时间段在 periods 中的索引。
+//
+// 与两两比较的 Overlap 不同，该函数计算的是时间上的连通分量：若 A 与 B 重叠、B 与 C 重叠，
+// 即便 A 与 C 并不重叠，三者也将被归入同一组。函数通过按开始时间排序后进行扫描线遍历实现，时间复杂度为 O(n log n)。
+//
+// 关键行为说明：
+//  - 与 Overlap 保持一致，边界相接的时间段视为重叠
+//  - 返回的分组按组内最早的开始时间升序排列，组内索引按升序排列
+//  - 当 periods 为空时返回 nil
+//
+// 使用建议：
+// 适用于会议室预订、资源排班等需要找出相互冲突的时间段集合的场景。
+func OverlapGroups(periods []Period) [][]int {
+    if len(periods) == 0 {
+        return nil
+    }
+
+    indexes := make([]int, len(periods))
+    for i := range indexes {
+        indexes[i] = i
+    }
+    sort.SliceStable(indexes, func(i, j int) bool {
+        return periods[indexes[i]].Start().Before(periods[indexes[j]].Start())
+    })
+
+    var groups [][]int
+    var group []int
+    var end time.Time
+    for _, i := range indexes {
+        p := periods[i]
+        if group != nil && p.Start().After(end) {
+            sort.Ints(group)
+            groups = append(groups, group)
+            group = nil
+        }
+        if group == nil || p.End().After(end) {
+            end = p.End()
+        }
+        group = append(group, i)
+    }
+    sort.Ints(group)
+    return append(groups, group)
+}
+
+// MergePeriods 将一组时间段中相互重叠或相接的部分合并，返回按开始时间升序排列且互不重叠的时间段。
+//
+// 该函数基于 OverlapGroups 实现，每个连通分组将被合并为一个从组内最早开始时间至最晚结束时间的时间段。
+//
+// 关键行为说明：
+//  - 与 Overlap 保持一致，边界相接的时间段将被合并
+//  - 当 periods 为空时返回 nil
+func MergePeriods(periods []Period) []Period {
+    groups := OverlapGroups(periods)
+    if groups == nil {
+        return nil
+    }
+
+    merged := make([]Period, 0, len(groups))
+    for _, group := range groups {
+        start, end := periods[group[0]].Start(), periods[group[0]].End()
+        for _, i := range group[1:] {
+            start = Min(start, periods[i].Start())
+            end = Max(end, periods[i].End())
+        }
+        merged = append(merged, NewPeriod(start, end))
+    }
+    return merged
+}
+
+// FreeSlots 返回在 bound 范围内未被任何 busy 时间段覆盖的空闲时间段，结果按开始时间升序排列。
+//
+// busy 将首先通过 MergePeriods 进行合并，随后计算其在 bound 范围内的补集，超出 bound 的部分将被忽略。
+// 可选参数 minDuration 用于指定空闲时间段的最短时长，短于该时长的空闲时间段将被忽略。
+//
+// 关键行为说明：
+//  - 空闲时间段的边界与相邻的忙碌时间段相接，时长为零的空闲时间段始终会被忽略
+//  - 当 bound 被完全占用时返回 nil，当 busy 为空时返回仅包含 bound 的切片
+//
+// 使用建议：
+// 适用于会议安排、预约系统等需要查找可用时间的场景。
+func FreeSlots(bound Period, busy []Period, minDuration ...time.Duration) []Period {
+    var shortest time.Duration
+    if len(minDuration) > 0 {
+        shortest = minDuration[0]
+    }
+
+    var slots []Period
+    appendSlot := func(start, end time.Time) {
+        if slot := NewPeriod(start, end); end.After(start) && slot.Duration() >= shortest {
+            slots = append(slots, slot)
+        }
+    }
+
+    cursor := bound.Start()
+    for _, p := range MergePeriods(busy) {
+        if !p.Start().Before(bound.End()) {
+            break
+        }
+        if p.Start().After(cursor) {
+            appendSlot(cursor, p.Start())
+        }
+        cursor = Max(cursor, p.End())
+    }
+    if cursor.Before(bound.End()) {
+        appendSlot(cursor, bound.End())
+    }
+    return slots
+}
+
+// SubtractAll 返回 base 的并集中未被 remove 中任何时间段覆盖的部分，结果已合并且按开始时间升序排列，例如 "移除预留后剩余的可用时间"。
+//
+// base 与 remove 都将首先通过 MergePeriods 进行合并，随后对 base 中的每个时间段通过 FreeSlots 计算其在 remove 之外的部分。
+//
+// 关键行为说明：
+//  - 与 FreeSlots 一致，时长为零的剩余部分将被忽略
+//  - 当 remove 为空时返回合并后的 base，当 base 被完全覆盖时返回 nil
+func SubtractAll(base []Period, remove []Period) []Period {
+    remove = MergePeriods(remove)
+    var result []Period
+    for _, p := range MergePeriods(base) {
+        result = append(result, FreeSlots(p, remove)...)
+    }
+    return result
+}
+
+// Coverage 返回 periods 的并集在 bound 范围内覆盖的时长占 bound 持续时间的比例，取值范围为 0 至 1，例如 "本月已被预订的比例"。
+//
+// periods 将首先通过 MergePeriods 进行合并，因此重复预订的时间不会被重复计算，超出 bound 的部分将被裁剪。
+//
+// 关键行为说明：
+//  - 当 bound 的持续时间为零或负数时返回 0
+//  - 当 periods 为空时返回 0，当 bound 被完全覆盖时返回 1
+func Coverage(bound Period, periods []Period) float64 {
+    total := bound.Duration()
+    if total <= 0 {
+        return 0
+    }
+
+    var covered time.Duration
+    for _, p := range MergePeriods(periods) {
+        start, end := Max(p.Start(), bound.Start()), Min(p.End(), bound.End())
+        if end.After(start) {
+            covered += end.Sub(start)
+        }
+    }
+    return float64(covered) / float64(total)
+}
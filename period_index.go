@@ -0,0 +1,37 @@
+package chrono
+
+import (
+    "sort"
+    "time"
+)
+
+// NewPeriodIndex 基于一组时间段创建一个只读的时间段索引，适用于对同一组静态时间段进行大量 "某一时刻是否位于任意时间段内" 的查询。
+//
+// 创建时将通过 MergePeriods 合并相互重叠或相接的时间段并按开始时间排序，此后每次查询均通过二分查找完成，时间复杂度为 O(log n)。
+// 例如判断数百万个时间点是否位于维护窗口内时，相较于逐个遍历时间段具有显著的性能优势。
+//
+// 关键行为说明：
+//  - 索引创建后与 periods 互不影响，修改 periods 不会影响索引
+//  - 当 periods 为空时，任何时间都不在索引内
+func NewPeriodIndex(periods []Period) PeriodIndex {
+    return PeriodIndex{periods: MergePeriods(periods)}
+}
+
+// PeriodIndex 是通过 NewPeriodIndex 创建的只读时间段索引，可以在多个协程中并发查询。
+type PeriodIndex struct {
+    periods []Period // 合并后按开始时间升序排列且互不重叠的时间段
+}
+
+// Contains 判断时间 t 是否位于索引中的任意时间段内，与 Period.Between 一致，时间段的起始时间及结束时间均视为位于时间段内。
+func (x PeriodIndex) Contains(t time.Time) bool {
+    // 找到第一个开始时间晚于 t 的时间段，此前的时间段即为唯一可能包含 t 的时间段
+    i := sort.Search(len(x.periods), func(i int) bool {
+        return x.periods[i].Start().After(t)
+    })
+    return i > 0 && !x.periods[i-1].End().Before(t)
+}
+
+// Periods 返回索引中合并后按开始时间升序排列且互不重叠的时间段
+func (x PeriodIndex) Periods() []Period {
+    return append([]Period(nil), x.periods...)
+}
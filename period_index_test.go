@@ -0,0 +1,100 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "math/rand/v2"
+    "testing"
+    "time"
+)
+
+// randomPeriods 生成 n 个随机分布且可能相互重叠的时间段
+func randomPeriods(r *rand.Rand, start time.Time, n int) []chrono.Period {
+    periods := make([]chrono.Period, n)
+    for i := range periods {
+        begin := start.Add(time.Duration(r.IntN(n*100)) * time.Minute)
+        periods[i] = chrono.NewPeriod(begin, begin.Add(time.Duration(r.IntN(60)+1)*time.Minute))
+    }
+    return periods
+}
+
+// linearContains 逐个遍历时间段判断 t 是否位于任意时间段内
+func linearContains(periods []chrono.Period, t time.Time) bool {
+    for _, p := range periods {
+        if p.Between(t) {
+            return true
+        }
+    }
+    return false
+}
+
+func TestPeriodIndex_Contains(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    index := chrono.NewPeriodIndex([]chrono.Period{
+        chrono.NewPeriod(start.Add(4*time.Hour), start.Add(5*time.Hour)),
+        chrono.NewPeriod(start, start.Add(time.Hour)),
+        chrono.NewPeriod(start.Add(30*time.Minute), start.Add(2*time.Hour)),
+    })
+
+    var tests = []struct {
+        name     string
+        t        time.Time
+        expected bool
+    }{
+        {"BeforeAll", start.Add(-time.Nanosecond), false},
+        {"AtStart", start, true},
+        {"InsideMerged", start.Add(90 * time.Minute), true},
+        {"AtMergedEnd", start.Add(2 * time.Hour), true},
+        {"Gap", start.Add(3 * time.Hour), false},
+        {"AtLastStart", start.Add(4 * time.Hour), true},
+        {"AfterAll", start.Add(5*time.Hour + time.Nanosecond), false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if result := index.Contains(tt.t); result != tt.expected {
+                t.Errorf("Contains() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+
+    if periods := index.Periods(); len(periods) != 2 {
+        t.Errorf("Periods() = %v, want 2 merged periods", periods)
+    }
+    if chrono.NewPeriodIndex(nil).Contains(start) {
+        t.Errorf("empty index Contains() = true, want false")
+    }
+
+    r := rand.New(rand.NewPCG(1, 2))
+    periods := randomPeriods(r, start, 1000)
+    random := chrono.NewPeriodIndex(periods)
+    for i := 0; i < 10000; i++ {
+        at := start.Add(time.Duration(r.IntN(1000*100*60)) * time.Second)
+        if result, expected := random.Contains(at), linearContains(periods, at); result != expected {
+            t.Fatalf("Contains(%v) = %v, want %v", at, result, expected)
+        }
+    }
+}
+
+func BenchmarkPeriodIndex(b *testing.B) {
+    const periodCount, queryCount = 10000, 1000000
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    r := rand.New(rand.NewPCG(1, 2))
+    periods := randomPeriods(r, start, periodCount)
+    queries := make([]time.Time, queryCount)
+    for i := range queries {
+        queries[i] = start.Add(time.Duration(r.IntN(periodCount*100*60)) * time.Second)
+    }
+
+    b.Run("Index", func(b *testing.B) {
+        index := chrono.NewPeriodIndex(periods)
+        b.ResetTimer()
+        for i := 0; i < b.N; i++ {
+            index.Contains(queries[i%queryCount])
+        }
+    })
+    b.Run("Linear", func(b *testing.B) {
+        for i := 0; i < b.N; i++ {
+            linearContains(periods, queries[i%queryCount])
+        }
+    })
+}
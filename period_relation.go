@@ -0,0 +1,96 @@
+package chrono
+
+// PeriodRelation 描述了两个时间段之间的位置关系，取值为 Allen 区间代数中的 13 种基本关系。
+//
+// 相较于仅返回是否重叠的 Overlap，PeriodRelation 能够精确地描述两个时间段如何相互关联，适用于日程冲突提示等需要说明冲突方式的场景。
+// 除 PeriodRelationEquals 外，每种关系都存在一个互逆的关系，即当 p.Relation(q) 为 PeriodRelationBefore 时，q.Relation(p) 为 PeriodRelationAfter。
+type PeriodRelation int
+
+const (
+    PeriodRelationBefore       PeriodRelation = iota // p 在 q 开始之前结束，二者之间存在间隔
+    PeriodRelationMeets                              // p 的结束时间恰好是 q 的开始时间
+    PeriodRelationOverlaps                           // p 先于 q 开始，并在 q 结束前结束，二者部分重叠
+    PeriodRelationStarts                             // p 与 q 同时开始，并在 q 结束前结束
+    PeriodRelationDuring                             // p 完全位于 q 的内部，且不与 q 的任何边界重合
+    PeriodRelationFinishes                           // p 晚于 q 开始，并与 q 同时结束
+    PeriodRelationEquals                             // p 与 q 的开始时间与结束时间分别相同
+    PeriodRelationFinishedBy                         // PeriodRelationFinishes 的逆关系，q 晚于 p 开始，并与 p 同时结束
+    PeriodRelationContains                           // PeriodRelationDuring 的逆关系，q 完全位于 p 的内部
+    PeriodRelationStartedBy                          // PeriodRelationStarts 的逆关系，p 与 q 同时开始，q 先于 p 结束
+    PeriodRelationOverlappedBy                       // PeriodRelationOverlaps 的逆关系，q 先于 p 开始，并在 p 结束前结束
+    PeriodRelationMetBy                              // PeriodRelationMeets 的逆关系，q 的结束时间恰好是 p 的开始时间
+    PeriodRelationAfter                              // PeriodRelationBefore 的逆关系，p 在 q 结束之后开始，二者之间存在间隔
+)
+
+var periodRelationNames = [...]string{
+    PeriodRelationBefore:       "before",
+    PeriodRelationMeets:        "meets",
+    PeriodRelationOverlaps:     "overlaps",
+    PeriodRelationStarts:       "starts",
+    PeriodRelationDuring:       "during",
+    PeriodRelationFinishes:     "finishes",
+    PeriodRelationEquals:       "equals",
+    PeriodRelationFinishedBy:   "finished by",
+    PeriodRelationContains:     "contains",
+    PeriodRelationStartedBy:    "started by",
+    PeriodRelationOverlappedBy: "overlapped by",
+    PeriodRelationMetBy:        "met by",
+    PeriodRelationAfter:        "after",
+}
+
+// String 返回关系的英文描述，例如 "overlapped by"，适用于拼接冲突提示信息
+func (r PeriodRelation) String() string {
+    if r < 0 || int(r) >= len(periodRelationNames) {
+        return "unknown"
+    }
+    return periodRelationNames[r]
+}
+
+// Inverse 返回互逆的关系，即 q.Relation(p) 的结果，PeriodRelationEquals 的逆关系为其自身
+func (r PeriodRelation) Inverse() PeriodRelation {
+    return PeriodRelationAfter - r
+}
+
+// Relation 返回时间段 p 相对于 other 的位置关系。
+//
+// 关系完全由两个时间段的端点比较得出，端点的比较基于 time.Time 所表示的时刻，不受时区的影响。
+//
+// 关键行为说明：
+//  - 当两个时间段的开始时间与结束时间分别相同时，总是返回 PeriodRelationEquals
+//  - 长度为零的时间段同样可以参与比较，但其结果在 Allen 区间代数中可能存在歧义，例如与端点重合的瞬时时间段将被视为 Meets 或 MetBy
+//
+// 使用建议：
+// 确保输入的时间段是有效的，即开始时间不大于结束时间，必要时可先调用 Normalize。
+func (p Period) Relation(other Period) PeriodRelation {
+    startCmp := p[0].Compare(other[0])
+    endCmp := p[1].Compare(other[1])
+
+    switch {
+    case startCmp == 0 && endCmp == 0:
+        return PeriodRelationEquals
+    case p[1].Before(other[0]):
+        return PeriodRelationBefore
+    case p[1].Equal(other[0]):
+        return PeriodRelationMeets
+    case p[0].After(other[1]):
+        return PeriodRelationAfter
+    case p[0].Equal(other[1]):
+        return PeriodRelationMetBy
+    case startCmp == 0 && endCmp < 0:
+        return PeriodRelationStarts
+    case startCmp == 0:
+        return PeriodRelationStartedBy
+    case endCmp == 0 && startCmp > 0:
+        return PeriodRelationFinishes
+    case endCmp == 0:
+        return PeriodRelationFinishedBy
+    case startCmp > 0 && endCmp < 0:
+        return PeriodRelationDuring
+    case startCmp < 0 && endCmp > 0:
+        return PeriodRelationContains
+    case startCmp < 0:
+        return PeriodRelationOverlaps
+    default:
+        return PeriodRelationOverlappedBy
+    }
+}
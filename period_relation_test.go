@@ -0,0 +1,44 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestPeriod_Relation(t *testing.T) {
+    at := func(hour int) time.Time {
+        return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC)
+    }
+    reference := chrono.NewPeriod(at(10), at(14))
+
+    var tests = []struct {
+        period   chrono.Period
+        expected chrono.PeriodRelation
+    }{
+        {chrono.NewPeriod(at(6), at(8)), chrono.PeriodRelationBefore},
+        {chrono.NewPeriod(at(8), at(10)), chrono.PeriodRelationMeets},
+        {chrono.NewPeriod(at(8), at(12)), chrono.PeriodRelationOverlaps},
+        {chrono.NewPeriod(at(10), at(12)), chrono.PeriodRelationStarts},
+        {chrono.NewPeriod(at(11), at(13)), chrono.PeriodRelationDuring},
+        {chrono.NewPeriod(at(12), at(14)), chrono.PeriodRelationFinishes},
+        {chrono.NewPeriod(at(10), at(14)), chrono.PeriodRelationEquals},
+        {chrono.NewPeriod(at(8), at(14)), chrono.PeriodRelationFinishedBy},
+        {chrono.NewPeriod(at(8), at(16)), chrono.PeriodRelationContains},
+        {chrono.NewPeriod(at(10), at(16)), chrono.PeriodRelationStartedBy},
+        {chrono.NewPeriod(at(12), at(16)), chrono.PeriodRelationOverlappedBy},
+        {chrono.NewPeriod(at(14), at(16)), chrono.PeriodRelationMetBy},
+        {chrono.NewPeriod(at(16), at(18)), chrono.PeriodRelationAfter},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.expected.String(), func(t *testing.T) {
+            if result := tt.period.Relation(reference); result != tt.expected {
+                t.Errorf("Relation() = %v, want %v", result, tt.expected)
+            }
+            if result := reference.Relation(tt.period); result != tt.expected.Inverse() {
+                t.Errorf("inverse Relation() = %v, want %v", result, tt.expected.Inverse())
+            }
+        })
+    }
+}
@@ -0,0 +1,108 @@
+package chrono
+
+import (
+    "database/sql"
+    "database/sql/driver"
+    "fmt"
+    "strings"
+    "time"
+)
+
+var (
+    _ sql.Scanner   = (*Period)(nil)
+    _ driver.Valuer = Period{}
+)
+
+// rangeTimeLayout 是输出 Postgres 范围字面量时使用的时间格式，Postgres 的时间戳精度为微秒
+const rangeTimeLayout = "2006-01-02 15:04:05.999999-07:00"
+
+// rangeTimeLayouts 是解析 Postgres 范围字面量时支持的时间格式，覆盖了 Postgres 输出的各种时区偏移写法
+var rangeTimeLayouts = []string{
+    "2006-01-02 15:04:05.999999999-07",
+    "2006-01-02 15:04:05.999999999-07:00",
+    "2006-01-02 15:04:05.999999999-07:00:00",
+    time.RFC3339Nano,
+}
+
+// Value 实现了 driver.Valuer 接口，将时间段格式化为 Postgres tstzrange 的范围字面量，例如 ["2023-10-01 00:00:00+00:00","2023-10-07 23:59:59+00:00"]。
+//
+// 关键行为说明：
+//  - 与 Period 的语义一致，输出的范围两端均为闭区间
+//  - Postgres 的时间戳精度为微秒，微秒以下的部分将被截断
+//  - 零值的时间段将被输出为 NULL
+func (p Period) Value() (driver.Value, error) {
+    if p.IsZero() {
+        return nil, nil
+    }
+    return fmt.Sprintf(`["%s","%s"]`, p[0].Format(rangeTimeLayout), p[1].Format(rangeTimeLayout)), nil
+}
+
+// Scan 实现了 sql.Scanner 接口，从 Postgres tstzrange 的范围字面量中解析时间段，例如 ["2023-10-01 00:00:00+00","2023-10-08 00:00:00+00")。
+//
+// 由于 Period 的两端均为闭区间，开区间的端点将按照 Postgres 的微秒精度转换为相邻的闭区间端点，
+// 即 [a,b) 将被转换为 [a,b-1µs]，(a,b] 将被转换为 [a+1µs,b]。
+//
+// 关键行为说明：
+//  - 支持 string 与 []byte 类型的输入，NULL 将被解析为零值的时间段
+//  - 空范围（empty）及无界范围不受支持，将返回错误
+//  - 与 NewPeriod 一致，开始时间晚于结束时间时两者将被交换
+func (p *Period) Scan(src any) error {
+    var s string
+    switch v := src.(type) {
+    case nil:
+        *p = Period{}
+        return nil
+    case string:
+        s = v
+    case []byte:
+        s = string(v)
+    default:
+        return fmt.Errorf("chrono: cannot scan %T into Period", src)
+    }
+
+    literal := strings.TrimSpace(s)
+    if len(literal) < 2 {
+        return fmt.Errorf("chrono: invalid range %q", s)
+    }
+    lower, upper := literal[0], literal[len(literal)-1]
+    if (lower != '[' && lower != '(') || (upper != ']' && upper != ')') {
+        return fmt.Errorf("chrono: invalid range %q: missing bounds", s)
+    }
+    start, end, ok := strings.Cut(literal[1:len(literal)-1], ",")
+    if !ok {
+        return fmt.Errorf("chrono: invalid range %q: missing separator", s)
+    }
+
+    st, err := parseRangeTime(start)
+    if err != nil {
+        return fmt.Errorf("chrono: invalid range %q: %w", s, err)
+    }
+    et, err := parseRangeTime(end)
+    if err != nil {
+        return fmt.Errorf("chrono: invalid range %q: %w", s, err)
+    }
+    if lower == '(' {
+        st = st.Add(Microsecond)
+    }
+    if upper == ')' {
+        et = et.Add(-Microsecond)
+    }
+    *p = NewPeriod(st, et)
+    return nil
+}
+
+// parseRangeTime 解析范围字面量中的单个端点，端点可以被双引号包裹
+func parseRangeTime(s string) (time.Time, error) {
+    s = strings.Trim(strings.TrimSpace(s), `"`)
+    if s == "" {
+        return time.Time{}, fmt.Errorf("unbounded range is not supported")
+    }
+    var err error
+    for _, layout := range rangeTimeLayouts {
+        var t time.Time
+        if t, err = time.Parse(layout, s); err == nil {
+            return t, nil
+        }
+    }
+    return time.Time{}, err
+}
@@ -0,0 +1,44 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestPeriod_ScanValue(t *testing.T) {
+    location := time.FixedZone("UTC+8", 8*60*60)
+    period := chrono.NewPeriod(
+        time.Date(2023, 10, 1, 0, 0, 0, 123456000, location),
+        time.Date(2023, 10, 7, 23, 59, 59, 0, location),
+    )
+
+    value, err := period.Value()
+    if err != nil {
+        t.Fatalf("Value() error = %v", err)
+    }
+    var scanned chrono.Period
+    if err = scanned.Scan(value); err != nil {
+        t.Fatalf("Scan(%v) error = %v", value, err)
+    }
+    if !scanned.Equal(period) {
+        t.Errorf("Scan(Value()) = %v, want %v", scanned, period)
+    }
+
+    if err = scanned.Scan([]byte(`["2023-10-01 00:00:00+00","2023-10-08 00:00:00+00")`)); err != nil {
+        t.Fatalf("Scan() error = %v", err)
+    }
+    expected := chrono.NewPeriod(
+        time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+        time.Date(2023, 10, 7, 23, 59, 59, 999999000, time.UTC),
+    )
+    if !scanned.Equal(expected) {
+        t.Errorf("Scan() = %v, want %v", scanned, expected)
+    }
+
+    for _, src := range []any{"empty", `[,"2023-10-08 00:00:00+00")`, 42} {
+        if err = scanned.Scan(src); err == nil {
+            t.Errorf("Scan(%v) error = nil, want error", src)
+        }
+    }
+}
@@ -0,0 +1,788 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "math"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestOverlapGroups(t *testing.T) {
+    base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+    at := func(hour int) time.Time {
+        return base.Add(time.Duration(hour) * time.Hour)
+    }
+
+    tests := []struct {
+        name     string
+        periods  []chrono.Period
+        expected [][]int
+    }{
+        {
+            name:     "Empty",
+            periods:  nil,
+            expected: nil,
+        },
+        {
+            name: "Chain",
+            periods: []chrono.Period{
+                chrono.NewPeriod(at(0), at(2)),
+                chrono.NewPeriod(at(1), at(4)),
+                chrono.NewPeriod(at(3), at(5)),
+            },
+            expected: [][]int{{0, 1, 2}},
+        },
+        {
+            name: "Disjoint",
+            periods: []chrono.Period{
+                chrono.NewPeriod(at(6), at(7)),
+                chrono.NewPeriod(at(0), at(1)),
+                chrono.NewPeriod(at(3), at(4)),
+                chrono.NewPeriod(at(0), at(2)),
+            },
+            expected: [][]int{{1, 3}, {2}, {0}},
+        },
+        {
+            name: "Touching",
+            periods: []chrono.Period{
+                chrono.NewPeriod(at(0), at(1)),
+                chrono.NewPeriod(at(1), at(2)),
+            },
+            expected: [][]int{{0, 1}},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := chrono.OverlapGroups(tt.periods)
+            if !reflect.DeepEqual(result, tt.expected) {
+                t.Errorf("OverlapGroups() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+}
+
+func TestPeriod_ShiftDate(t *testing.T) {
+    p := chrono.NewPeriod(
+        time.Date(2023, 1, 25, 0, 0, 0, 0, time.Local),
+        time.Date(2023, 1, 31, 12, 0, 0, 0, time.Local),
+    )
+
+    result := p.ShiftDate(0, 0, 7)
+    expected := chrono.NewPeriod(
+        time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local),
+        time.Date(2023, 2, 7, 12, 0, 0, 0, time.Local),
+    )
+    if !result.Start().Equal(expected.Start()) || !result.End().Equal(expected.End()) {
+        t.Errorf("ShiftDate() = %v, want %v", result, expected)
+    }
+}
+
+func TestPeriod_Extend(t *testing.T) {
+    start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.Local)
+    p := chrono.NewPeriod(start, start.Add(time.Hour))
+
+    result := p.Extend(-time.Hour, -time.Hour)
+    if result.Start().After(result.End()) {
+        t.Errorf("Extend() = %v, start after end", result)
+    }
+    if result.Duration() != time.Hour {
+        t.Errorf("Extend().Duration() = %v, want %v", result.Duration(), time.Hour)
+    }
+}
+
+func TestPeriod_In(t *testing.T) {
+    location, err := time.LoadLocation("Asia/Shanghai")
+    if err != nil {
+        t.Skip(err)
+    }
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    p := chrono.NewPeriod(start, start.Add(36*time.Hour))
+    other := chrono.NewPeriod(start.Add(30*time.Hour), start.Add(48*time.Hour))
+
+    result := p.In(location)
+    if result.Start().Location() != location || result.End().Location() != location {
+        t.Errorf("In() = %v, want both endpoints in %v", result, location)
+    }
+    if !result.Equal(p) {
+        t.Errorf("In() = %v, want the same instants as %v", result, p)
+    }
+    if result.Duration() != p.Duration() {
+        t.Errorf("In().Duration() = %v, want %v", result.Duration(), p.Duration())
+    }
+    if result.Overlap(other) != p.Overlap(other) || result.Overlap(other.In(location)) != p.Overlap(other) {
+        t.Errorf("In().Overlap() = %v, want %v", result.Overlap(other), p.Overlap(other))
+    }
+    if result.Start().Hour() != 8 {
+        t.Errorf("In().Start().Hour() = %d, want 8", result.Start().Hour())
+    }
+}
+
+func TestPeriod_WithStartEnd(t *testing.T) {
+    at := func(hour int) time.Time {
+        return time.Date(2023, 10, 1, hour, 0, 0, 0, time.UTC)
+    }
+    p := chrono.NewPeriod(at(10), at(12))
+
+    var tests = []struct {
+        name     string
+        result   chrono.Period
+        expected chrono.Period
+    }{
+        {"WithStart", p.WithStart(at(8)), chrono.Period{at(8), at(12)}},
+        {"WithStartPastEnd", p.WithStart(at(14)), chrono.Period{at(12), at(14)}},
+        {"WithEnd", p.WithEnd(at(16)), chrono.Period{at(10), at(16)}},
+        {"WithEndBeforeStart", p.WithEnd(at(6)), chrono.Period{at(6), at(10)}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if !tt.result.Equal(tt.expected) {
+                t.Errorf("%s = %v, want %v", tt.name, tt.result, tt.expected)
+            }
+        })
+    }
+    if !p.Equal(chrono.Period{at(10), at(12)}) {
+        t.Errorf("original period was modified: %v", p)
+    }
+}
+
+func TestPeriod_Cap(t *testing.T) {
+    at := func(hour int) time.Time {
+        return time.Date(2023, 10, 1, hour, 0, 0, 0, time.UTC)
+    }
+    p := chrono.NewPeriod(at(10), at(14))
+
+    var tests = []struct {
+        name     string
+        result   chrono.Period
+        expected chrono.Period
+    }{
+        {"Cap", p.Cap(time.Hour), chrono.Period{at(10), at(11)}},
+        {"CapLonger", p.Cap(8 * time.Hour), p},
+        {"CapExact", p.Cap(4 * time.Hour), p},
+        {"CapNegative", p.Cap(-time.Hour), chrono.Period{at(10), at(10)}},
+        {"CapEnd", p.CapEnd(at(12)), chrono.Period{at(10), at(12)}},
+        {"CapEndLater", p.CapEnd(at(16)), p},
+        {"CapEndBeforeStart", p.CapEnd(at(8)), chrono.Period{at(10), at(10)}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if !tt.result.Equal(tt.expected) {
+                t.Errorf("%s = %v, want %v", tt.name, tt.result, tt.expected)
+            }
+        })
+    }
+}
+
+func TestPeriod_Quartiles(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+
+    p := chrono.NewPeriod(start, start.Add(8*time.Hour))
+    if mid := p.Midpoint(); !mid.Equal(start.Add(4 * time.Hour)) {
+        t.Errorf("Midpoint() = %v, want %v", mid, start.Add(4*time.Hour))
+    }
+    var tests = []struct {
+        name     string
+        period   chrono.Period
+        expected [3]time.Time
+    }{
+        {"Hours", p, [3]time.Time{start.Add(2 * time.Hour), start.Add(4 * time.Hour), start.Add(6 * time.Hour)}},
+        {"Indivisible", chrono.NewPeriod(start, start.Add(7)), [3]time.Time{start.Add(1), start.Add(3), start.Add(5)}},
+        {"Long", chrono.NewPeriod(start, start.Add(time.Duration(math.MaxInt64))), [3]time.Time{
+            start.Add(time.Duration(math.MaxInt64 / 4)),
+            start.Add(time.Duration(math.MaxInt64 / 2)),
+            start.Add(6917529027641081855), // math.MaxInt64 * 3 / 4 向下取整
+        }},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            quartiles := tt.period.Quartiles()
+            for i := range quartiles {
+                if !quartiles[i].Equal(tt.expected[i]) {
+                    t.Errorf("Quartiles()[%d] = %v, want %v", i, quartiles[i], tt.expected[i])
+                }
+            }
+        })
+    }
+
+    zero := chrono.NewPeriod(start, start)
+    if mid := zero.Midpoint(); !mid.Equal(start) {
+        t.Errorf("Midpoint() of zero-length period = %v, want %v", mid, start)
+    }
+    for i, mark := range zero.Quartiles() {
+        if !mark.Equal(start) {
+            t.Errorf("Quartiles()[%d] of zero-length period = %v, want %v", i, mark, start)
+        }
+    }
+}
+
+func TestPeriod_IsPastFutureCurrent(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    p := chrono.NewPeriod(start, start.Add(time.Hour))
+
+    var tests = []struct {
+        name    string
+        now     time.Time
+        past    bool
+        future  bool
+        current bool
+    }{
+        {"BeforeStart", start.Add(-time.Nanosecond), false, true, false},
+        {"AtStart", start, false, false, true},
+        {"Inside", start.Add(30 * time.Minute), false, false, true},
+        {"AtEnd", start.Add(time.Hour), false, false, true},
+        {"AfterEnd", start.Add(time.Hour + time.Nanosecond), true, false, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := p.IsPast(tt.now); got != tt.past {
+                t.Errorf("IsPast() = %v, want %v", got, tt.past)
+            }
+            if got := p.IsFuture(tt.now); got != tt.future {
+                t.Errorf("IsFuture() = %v, want %v", got, tt.future)
+            }
+            if got := p.IsCurrent(tt.now); got != tt.current {
+                t.Errorf("IsCurrent() = %v, want %v", got, tt.current)
+            }
+        })
+    }
+}
+
+func TestPeriod_EndsBeforeStartsAfter(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    p := chrono.NewPeriod(start, start.Add(time.Hour))
+
+    var tests = []struct {
+        name        string
+        t           time.Time
+        endsBefore  bool
+        startsAfter bool
+    }{
+        {"BeforeStart", start.Add(-time.Nanosecond), false, true},
+        {"AtStart", start, false, false},
+        {"AtEnd", start.Add(time.Hour), false, false},
+        {"AfterEnd", start.Add(time.Hour + time.Nanosecond), true, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := p.EndsBefore(tt.t); got != tt.endsBefore || p.Before(tt.t) != got {
+                t.Errorf("EndsBefore() = %v, Before() = %v, want %v", got, p.Before(tt.t), tt.endsBefore)
+            }
+            if got := p.StartsAfter(tt.t); got != tt.startsAfter || p.After(tt.t) != got {
+                t.Errorf("StartsAfter() = %v, After() = %v, want %v", got, p.After(tt.t), tt.startsAfter)
+            }
+        })
+    }
+}
+
+func TestPeriod_EachDate(t *testing.T) {
+    location, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        t.Skip(err)
+    }
+    // 2023-11-05 为夏令时回拨日，当天共 25 小时
+    p := chrono.NewPeriod(time.Date(2023, 11, 4, 8, 0, 0, 0, location), time.Date(2023, 11, 6, 0, 0, 0, 0, location))
+
+    var dates []time.Time
+    p.EachDate(func(date time.Time) bool {
+        dates = append(dates, date)
+        return true
+    })
+    want := []time.Time{
+        time.Date(2023, 11, 4, 0, 0, 0, 0, location),
+        time.Date(2023, 11, 5, 0, 0, 0, 0, location),
+        time.Date(2023, 11, 6, 0, 0, 0, 0, location),
+    }
+    if len(dates) != len(want) {
+        t.Fatalf("EachDate() yielded %v, want %v", dates, want)
+    }
+    for i := range want {
+        if !dates[i].Equal(want[i]) {
+            t.Errorf("EachDate()[%d] = %v, want %v", i, dates[i], want[i])
+        }
+    }
+
+    var count int
+    p.EachDate(func(date time.Time) bool {
+        count++
+        return false
+    })
+    if count != 1 {
+        t.Errorf("EachDate() called fn %d times after it returned false, want 1", count)
+    }
+}
+
+func TestPeriod_EachStep(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    p := chrono.NewPeriod(start, start.AddDate(0, 0, 6))
+
+    var tests = []struct {
+        name  string
+        step  time.Duration
+        count int
+    }{
+        {"EveryDay", 24 * time.Hour, 7},
+        {"EveryTwoDays", 48 * time.Hour, 4},
+        {"EveryFourDays", 96 * time.Hour, 2},
+        {"NonPositive", 0, 0},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var points []time.Time
+            p.EachStep(tt.step, func(t time.Time) bool {
+                points = append(points, t)
+                return true
+            })
+            if len(points) != tt.count {
+                t.Fatalf("EachStep() yielded %d points, want %d", len(points), tt.count)
+            }
+            for i, point := range points {
+                if expected := start.Add(time.Duration(i) * tt.step); !point.Equal(expected) {
+                    t.Errorf("EachStep()[%d] = %v, want %v", i, point, expected)
+                }
+            }
+        })
+    }
+}
+
+func TestPeriod_OverlapsWith(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    first := chrono.NewPeriod(start, start.Add(time.Hour))
+    touching := chrono.NewPeriod(start.Add(time.Hour), start.Add(2*time.Hour))
+    overlapping := chrono.NewPeriod(start.Add(30*time.Minute), start.Add(2*time.Hour))
+    instant := chrono.NewPeriod(start.Add(time.Hour), start.Add(time.Hour))
+
+    var tests = []struct {
+        name           string
+        other          chrono.Period
+        startInclusive bool
+        endInclusive   bool
+        expected       bool
+    }{
+        {"TouchingClosed", touching, true, true, true},
+        {"TouchingHalfOpen", touching, true, false, false},
+        {"TouchingLeftOpen", touching, false, true, false},
+        {"TouchingOpen", touching, false, false, false},
+        {"OverlappingOpen", overlapping, false, false, true},
+        {"InstantAtEndClosed", instant, true, true, true},
+        {"InstantAtEndHalfOpen", instant, true, false, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if result := first.OverlapsWith(tt.other, tt.startInclusive, tt.endInclusive); result != tt.expected {
+                t.Errorf("OverlapsWith() = %v, want %v", result, tt.expected)
+            }
+            if result := tt.other.OverlapsWith(first, tt.startInclusive, tt.endInclusive); result != tt.expected {
+                t.Errorf("reversed OverlapsWith() = %v, want %v", result, tt.expected)
+            }
+            if tt.startInclusive && tt.endInclusive && first.Overlap(tt.other) != tt.expected {
+                t.Errorf("Overlap() = %v, want it to match OverlapsWith() = %v", first.Overlap(tt.other), tt.expected)
+            }
+        })
+    }
+}
+
+func TestPeriod_MonthsYears(t *testing.T) {
+    date := func(year int, month time.Month, day int) time.Time {
+        return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+    }
+    var tests = []struct {
+        name   string
+        period chrono.Period
+        months int
+        years  int
+    }{
+        {"PartialMonth", chrono.NewPeriod(date(2023, 1, 15), date(2023, 3, 10)), 1, 0},
+        {"LeapFebruary", chrono.NewPeriod(date(2024, 1, 29), date(2024, 2, 29)), 1, 0},
+        {"LeapFebruaryShort", chrono.NewPeriod(date(2024, 1, 29), date(2024, 2, 28)), 0, 0},
+        {"MonthEndIntoLeapFebruary", chrono.NewPeriod(date(2024, 1, 31), date(2024, 2, 29)), 1, 0},
+        {"FromLeapDay", chrono.NewPeriod(date(2024, 2, 29), date(2024, 3, 28)), 0, 0},
+        {"LeapDayToNextYear", chrono.NewPeriod(date(2024, 2, 29), date(2025, 2, 28)), 12, 1},
+        {"MultipleYears", chrono.NewPeriod(date(2020, 6, 1), date(2023, 8, 15)), 38, 3},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if months := tt.period.Months(); months != tt.months {
+                t.Errorf("Months() = %d, want %d", months, tt.months)
+            }
+            if years := tt.period.Years(); years != tt.years {
+                t.Errorf("Years() = %d, want %d", years, tt.years)
+            }
+        })
+    }
+}
+
+func TestParsePeriod(t *testing.T) {
+    p := chrono.MustParsePeriod("2023-10-02T00:00:00Z/2023-10-01T00:00:00Z")
+    if !p.Start().Equal(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)) || p.Duration() != 24*time.Hour {
+        t.Errorf("MustParsePeriod() = %v", p)
+    }
+
+    for _, s := range []string{"", "2023-10-01T00:00:00Z", "2023-10-01/2023-10-02"} {
+        if _, err := chrono.ParsePeriod(s); err == nil {
+            t.Errorf("ParsePeriod(%q) error = nil, want error", s)
+        }
+    }
+}
+
+func TestPeriod_Percent(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+    p := chrono.NewPeriod(start, start.Add(4*time.Hour))
+    empty := chrono.NewPeriod(start, start)
+
+    tests := []struct {
+        name     string
+        period   chrono.Period
+        t        time.Time
+        expected float64
+    }{
+        {name: "Before", period: p, t: start.Add(-time.Hour), expected: 0},
+        {name: "Start", period: p, t: start, expected: 0},
+        {name: "Middle", period: p, t: start.Add(time.Hour), expected: 0.25},
+        {name: "End", period: p, t: start.Add(4 * time.Hour), expected: 1},
+        {name: "After", period: p, t: start.Add(5 * time.Hour), expected: 1},
+        {name: "Empty before", period: empty, t: start.Add(-time.Hour), expected: 0},
+        {name: "Empty at", period: empty, t: start, expected: 1},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if result := tt.period.Percent(tt.t); result != tt.expected {
+                t.Errorf("Percent() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+}
+
+func TestPeriod_Days(t *testing.T) {
+    start := time.Date(2023, 10, 1, 23, 59, 0, 0, time.Local)
+    tests := []struct {
+        name         string
+        period       chrono.Period
+        days         int
+        daysCeil     int
+        daysRound    int
+        calendarDays int
+    }{
+        {
+            name:         "Across midnight",
+            period:       chrono.NewPeriod(start, start.Add(2*time.Minute)),
+            days:         0,
+            daysCeil:     1,
+            daysRound:    0,
+            calendarDays: 2,
+        },
+        {
+            name:         "47 hours",
+            period:       chrono.NewPeriod(start, start.Add(47*time.Hour)),
+            days:         1,
+            daysCeil:     2,
+            daysRound:    2,
+            calendarDays: 3,
+        },
+        {
+            name:         "Midnight to midnight",
+            period:       chrono.NewPeriod(start.Add(time.Minute), start.Add(time.Minute+24*time.Hour)),
+            days:         1,
+            daysCeil:     1,
+            daysRound:    1,
+            calendarDays: 1,
+        },
+        {
+            name:         "Empty",
+            period:       chrono.NewPeriod(start, start),
+            days:         0,
+            daysCeil:     0,
+            daysRound:    0,
+            calendarDays: 1,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            p := tt.period
+            if p.Days() != tt.days || p.DaysCeil() != tt.daysCeil || p.DaysRound() != tt.daysRound || p.CalendarDays() != tt.calendarDays {
+                t.Errorf("Days() = %d, DaysCeil() = %d, DaysRound() = %d, CalendarDays() = %d, want %d, %d, %d, %d",
+                    p.Days(), p.DaysCeil(), p.DaysRound(), p.CalendarDays(), tt.days, tt.daysCeil, tt.daysRound, tt.calendarDays)
+            }
+        })
+    }
+}
+
+func TestPeriod_Equal(t *testing.T) {
+    now := time.Now()
+    p1 := chrono.NewPeriod(now, now.Add(time.Hour))
+    p2 := chrono.NewPeriod(now.Round(0), now.Add(time.Hour).Round(0))
+
+    if !p1.Equal(p2) {
+        t.Errorf("Equal() = false, want true")
+    }
+    if p1.Compare(p2) != 0 {
+        t.Errorf("Compare() = %d, want 0", p1.Compare(p2))
+    }
+    if p3 := p1.Extend(0, time.Second); p1.Compare(p3) != -1 || p3.Compare(p1) != 1 || p1.Equal(p3) {
+        t.Errorf("Compare() = %d, want -1", p1.Compare(p3))
+    }
+}
+
+func TestPeriod_HumanDuration(t *testing.T) {
+    start := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+    tests := []struct {
+        name     string
+        period   chrono.Period
+        expected string
+    }{
+        {
+            name:     "14 months",
+            period:   chrono.NewPeriod(start, start.AddDate(0, 14, 0)),
+            expected: "1 year 2 months",
+        },
+        {
+            name:     "Mixed with truncated hours",
+            period:   chrono.NewPeriod(start, start.AddDate(2, 1, 3).Add(5*time.Hour)),
+            expected: "2 years 1 month 3 days",
+        },
+        {
+            name:     "Month end",
+            period:   chrono.NewPeriod(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)),
+            expected: "1 month 1 day",
+        },
+        {
+            name:     "Less than a day",
+            period:   chrono.NewPeriod(start, start.Add(time.Hour+30*time.Second)),
+            expected: "1 hour 30 seconds",
+        },
+        {
+            name:     "Empty",
+            period:   chrono.NewPeriod(start, start),
+            expected: "0 seconds",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := tt.period.HumanDuration(); got != tt.expected {
+                t.Errorf("HumanDuration() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestMergePeriods(t *testing.T) {
+    base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+    at := func(hour int) time.Time {
+        return base.Add(time.Duration(hour) * time.Hour)
+    }
+
+    merged := chrono.MergePeriods([]chrono.Period{
+        chrono.NewPeriod(at(6), at(7)),
+        chrono.NewPeriod(at(0), at(2)),
+        chrono.NewPeriod(at(1), at(3)),
+        chrono.NewPeriod(at(3), at(4)),
+    })
+    expected := []chrono.Period{chrono.NewPeriod(at(0), at(4)), chrono.NewPeriod(at(6), at(7))}
+    if !reflect.DeepEqual(merged, expected) {
+        t.Errorf("MergePeriods() = %v, want %v", merged, expected)
+    }
+}
+
+func TestFreeSlots(t *testing.T) {
+    base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+    at := func(hour int) time.Time {
+        return base.Add(time.Duration(hour) * time.Hour)
+    }
+    bound := chrono.NewPeriod(at(9), at(18))
+
+    tests := []struct {
+        name        string
+        busy        []chrono.Period
+        minDuration time.Duration
+        expected    []chrono.Period
+    }{
+        {
+            name:     "Fully free",
+            busy:     []chrono.Period{chrono.NewPeriod(at(0), at(8)), chrono.NewPeriod(at(19), at(20))},
+            expected: []chrono.Period{bound},
+        },
+        {
+            name:     "Fully busy",
+            busy:     []chrono.Period{chrono.NewPeriod(at(8), at(12)), chrono.NewPeriod(at(12), at(19))},
+            expected: nil,
+        },
+        {
+            name: "Gaps",
+            busy: []chrono.Period{
+                chrono.NewPeriod(at(10), at(11)),
+                chrono.NewPeriod(at(8), at(9)),
+                chrono.NewPeriod(at(13), at(17)),
+            },
+            expected: []chrono.Period{
+                chrono.NewPeriod(at(9), at(10)),
+                chrono.NewPeriod(at(11), at(13)),
+                chrono.NewPeriod(at(17), at(18)),
+            },
+        },
+        {
+            name: "Min duration",
+            busy: []chrono.Period{
+                chrono.NewPeriod(at(10), at(11)),
+                chrono.NewPeriod(at(13), at(17)),
+            },
+            minDuration: 2 * time.Hour,
+            expected:    []chrono.Period{chrono.NewPeriod(at(11), at(13))},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := chrono.FreeSlots(bound, tt.busy, tt.minDuration)
+            if !reflect.DeepEqual(result, tt.expected) {
+                t.Errorf("FreeSlots() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+}
+
+func TestPeriod_Normalize(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+    end := start.Add(50 * time.Hour)
+
+    inverted := chrono.Period{end, start}
+    if inverted.Duration() >= 0 {
+        t.Fatalf("Duration() = %v on an inverted literal, want negative", inverted.Duration())
+    }
+
+    normalized := inverted.Normalize()
+    if !normalized.Start().Equal(start) || !normalized.End().Equal(end) {
+        t.Errorf("Normalize() = %v, want %v", normalized, chrono.NewPeriod(start, end))
+    }
+    if normalized.Duration() != 50*time.Hour || normalized.Days() != 2 || normalized.Hours() != 50 {
+        t.Errorf("Duration() = %v, Days() = %d, Hours() = %d, want 50h, 2, 50",
+            normalized.Duration(), normalized.Days(), normalized.Hours())
+    }
+}
+
+func TestSubtractAll(t *testing.T) {
+    base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    at := func(hour int) time.Time {
+        return base.Add(time.Duration(hour) * time.Hour)
+    }
+
+    tests := []struct {
+        name     string
+        base     []chrono.Period
+        remove   []chrono.Period
+        expected []chrono.Period
+    }{
+        {
+            name:     "Nothing removed",
+            base:     []chrono.Period{chrono.NewPeriod(at(4), at(6)), chrono.NewPeriod(at(1), at(5))},
+            remove:   nil,
+            expected: []chrono.Period{chrono.NewPeriod(at(1), at(6))},
+        },
+        {
+            name: "Overlapping on both sides",
+            base: []chrono.Period{
+                chrono.NewPeriod(at(8), at(12)),
+                chrono.NewPeriod(at(10), at(14)),
+                chrono.NewPeriod(at(16), at(20)),
+            },
+            remove: []chrono.Period{
+                chrono.NewPeriod(at(9), at(10)),
+                chrono.NewPeriod(at(9), at(11)),
+                chrono.NewPeriod(at(13), at(17)),
+                chrono.NewPeriod(at(18), at(19)),
+            },
+            expected: []chrono.Period{
+                chrono.NewPeriod(at(8), at(9)),
+                chrono.NewPeriod(at(11), at(13)),
+                chrono.NewPeriod(at(17), at(18)),
+                chrono.NewPeriod(at(19), at(20)),
+            },
+        },
+        {
+            name:     "Fully removed",
+            base:     []chrono.Period{chrono.NewPeriod(at(1), at(2)), chrono.NewPeriod(at(3), at(4))},
+            remove:   []chrono.Period{chrono.NewPeriod(at(0), at(3)), chrono.NewPeriod(at(2), at(5))},
+            expected: nil,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := chrono.SubtractAll(tt.base, tt.remove)
+            if len(result) != len(tt.expected) {
+                t.Fatalf("SubtractAll() = %v, want %v", result, tt.expected)
+            }
+            for i := range result {
+                if !result[i].Equal(tt.expected[i]) {
+                    t.Errorf("SubtractAll()[%d] = %v, want %v", i, result[i], tt.expected[i])
+                }
+            }
+        })
+    }
+}
+
+func TestCoverage(t *testing.T) {
+    base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    at := func(hour int) time.Time {
+        return base.Add(time.Duration(hour) * time.Hour)
+    }
+    bound := chrono.NewPeriod(at(0), at(10))
+
+    tests := []struct {
+        name     string
+        periods  []chrono.Period
+        expected float64
+    }{
+        {name: "Empty", periods: nil, expected: 0},
+        {name: "Outside", periods: []chrono.Period{chrono.NewPeriod(at(12), at(14))}, expected: 0},
+        {
+            name: "Overlapping bookings",
+            periods: []chrono.Period{
+                chrono.NewPeriod(at(1), at(4)),
+                chrono.NewPeriod(at(2), at(5)),
+                chrono.NewPeriod(at(3), at(4)),
+            },
+            expected: 0.4,
+        },
+        {
+            name: "Clipped to bound",
+            periods: []chrono.Period{
+                chrono.NewPeriod(at(-5), at(2)),
+                chrono.NewPeriod(at(8), at(15)),
+            },
+            expected: 0.4,
+        },
+        {
+            name: "Double booked everywhere",
+            periods: []chrono.Period{
+                chrono.NewPeriod(at(-1), at(11)),
+                chrono.NewPeriod(at(0), at(10)),
+                chrono.NewPeriod(at(5), at(6)),
+            },
+            expected: 1,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := chrono.Coverage(bound, tt.periods)
+            if math.Abs(result-tt.expected) > 1e-9 || result > 1 {
+                t.Errorf("Coverage() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+
+    if result := chrono.Coverage(chrono.NewPeriod(at(1), at(1)), []chrono.Period{bound}); result != 0 {
+        t.Errorf("Coverage() of zero-length bound = %v, want 0", result)
+    }
+}
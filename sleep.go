@@ -0,0 +1,29 @@
+package chrono
+
+import (
+    "context"
+    "time"
+)
+
+// SleepUntil 阻塞当前协程直至到达绝对时间 t，或在 ctx 被取消时提前返回 ctx.Err()。
+//
+// 相较于手动创建 time.Timer 并与 ctx.Done() 进行 select，该函数确保了在提前返回时计时器被停止，避免了资源泄漏。
+//
+// 关键行为说明：
+//  - 当 t 早于或等于当前时间时立即返回 nil，即使 ctx 已经被取消
+//  - 到达 t 时返回 nil，等待过程中 ctx 被取消时返回 ctx.Err()
+//  - 等待时长基于调用时的 time.Until(t) 计算，此后系统时钟的跳变不会影响返回时刻
+func SleepUntil(ctx context.Context, t time.Time) error {
+    d := time.Until(t)
+    if d <= 0 {
+        return nil
+    }
+    timer := time.NewTimer(d)
+    defer timer.Stop()
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case <-timer.C:
+        return nil
+    }
+}
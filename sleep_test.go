@@ -0,0 +1,46 @@
+package chrono_test
+
+import (
+    "context"
+    "errors"
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestSleepUntil(t *testing.T) {
+    var tests = []struct {
+        name     string
+        deadline time.Duration
+        cancel   time.Duration
+        err      error
+        min, max time.Duration
+    }{
+        {"Deadline", 50 * time.Millisecond, time.Second, nil, 45 * time.Millisecond, 500 * time.Millisecond},
+        {"Past", -time.Second, time.Second, nil, 0, 10 * time.Millisecond},
+        {"Canceled", time.Minute, 20 * time.Millisecond, context.Canceled, 15 * time.Millisecond, 500 * time.Millisecond},
+        {"AlreadyCanceled", time.Minute, 0, context.Canceled, 0, 10 * time.Millisecond},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ctx, cancel := context.WithCancel(context.Background())
+            defer cancel()
+            if tt.cancel <= 0 {
+                cancel()
+            } else {
+                time.AfterFunc(tt.cancel, cancel)
+            }
+
+            start := time.Now()
+            err := chrono.SleepUntil(ctx, start.Add(tt.deadline))
+            elapsed := time.Since(start)
+            if !errors.Is(err, tt.err) {
+                t.Errorf("SleepUntil() error = %v, want %v", err, tt.err)
+            }
+            if elapsed < tt.min || elapsed > tt.max {
+                t.Errorf("SleepUntil() returned after %v, want between %v and %v", elapsed, tt.min, tt.max)
+            }
+        })
+    }
+}
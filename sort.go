@@ -0,0 +1,39 @@
+package chrono
+
+import (
+    "sort"
+    "time"
+)
+
+// SortTimes 将时间切片按照时间先后升序排列，排序将直接作用于 ts。
+//
+// 关键行为说明：
+//  - 排序是稳定的，相等的时间点将保持原有的相对顺序
+//  - 时间点的比较基于 time.Time.Before，不受时区及单调时钟读数的影响
+func SortTimes(ts []time.Time) {
+    sort.SliceStable(ts, func(i, j int) bool {
+        return ts[i].Before(ts[j])
+    })
+}
+
+// SortTimesDesc 将时间切片按照时间先后降序排列，排序将直接作用于 ts。
+//
+// 关键行为说明：
+//  - 排序是稳定的，相等的时间点将保持原有的相对顺序
+func SortTimesDesc(ts []time.Time) {
+    sort.SliceStable(ts, func(i, j int) bool {
+        return ts[i].After(ts[j])
+    })
+}
+
+// SortPeriods 将时间段切片升序排列，排序将直接作用于 ps。
+//
+// 时间段的顺序与 Period.Compare 一致，优先按照开始时间排序，开始时间相同时按照结束时间排序。
+//
+// 关键行为说明：
+//  - 排序是稳定的，开始时间与结束时间均相同的时间段将保持原有的相对顺序
+func SortPeriods(ps []Period) {
+    sort.SliceStable(ps, func(i, j int) bool {
+        return ps[i].Compare(ps[j]) < 0
+    })
+}
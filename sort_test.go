@@ -0,0 +1,46 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestSortPeriods(t *testing.T) {
+    base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+    at := func(hour int) time.Time {
+        return base.Add(time.Duration(hour) * time.Hour)
+    }
+    ps := []chrono.Period{
+        chrono.NewPeriod(at(2), at(3)),
+        chrono.NewPeriod(at(0), at(5)),
+        chrono.NewPeriod(at(0), at(1)),
+    }
+
+    chrono.SortPeriods(ps)
+    expected := []chrono.Period{
+        chrono.NewPeriod(at(0), at(1)),
+        chrono.NewPeriod(at(0), at(5)),
+        chrono.NewPeriod(at(2), at(3)),
+    }
+    for i := range ps {
+        if ps[i] != expected[i] {
+            t.Errorf("SortPeriods()[%d] = %v, want %v", i, ps[i], expected[i])
+        }
+    }
+}
+
+func TestSortTimes(t *testing.T) {
+    base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+    ts := []time.Time{base.Add(time.Hour), base, base.Add(2 * time.Hour)}
+
+    chrono.SortTimes(ts)
+    if !ts[0].Equal(base) || !ts[2].Equal(base.Add(2*time.Hour)) {
+        t.Errorf("SortTimes() = %v", ts)
+    }
+
+    chrono.SortTimesDesc(ts)
+    if !ts[0].Equal(base.Add(2*time.Hour)) || !ts[2].Equal(base) {
+        t.Errorf("SortTimesDesc() = %v", ts)
+    }
+}
@@ -0,0 +1,85 @@
+package chrono
+
+import (
+    "sync"
+    "time"
+)
+
+// StartStopwatch 创建并立即启动一个秒表。
+//
+// 秒表用于替代 "start := time.Now(); ...; time.Since(start)" 的重复写法，并支持分段计时。
+func StartStopwatch() *Stopwatch {
+    now := time.Now()
+    return &Stopwatch{
+        start: now,
+        lap:   now,
+    }
+}
+
+// Stopwatch 是一个支持分段计时的秒表，通过 StartStopwatch 创建。
+//
+// 秒表内部记录了启动时刻及每次分段的时刻，计时基于 time.Time 的单调时钟读数，不受系统时钟调整的影响。
+//
+// 关键行为说明：
+//  - 停止后 Elapsed 将返回停止时刻冻结的时长
+//  - 所有方法都是并发安全的
+type Stopwatch struct {
+    mu    sync.Mutex
+    start time.Time       // 启动时刻
+    lap   time.Time       // 上一次分段的时刻
+    stop  time.Time       // 停止时刻，零值表示未停止
+    laps  []time.Duration // 已记录的分段时长
+}
+
+// now 返回秒表当前的时刻，停止后将返回停止时刻
+func (s *Stopwatch) now() time.Time {
+    if !s.stop.IsZero() {
+        return s.stop
+    }
+    return time.Now()
+}
+
+// Elapsed 返回自启动以来经过的时长，停止后返回停止时刻冻结的时长。
+func (s *Stopwatch) Elapsed() time.Duration {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.now().Sub(s.start)
+}
+
+// Lap 记录一个分段，并返回自上一次分段（首次调用时为启动时刻）以来经过的时长。
+//
+// 停止后调用将返回上一次分段至停止时刻的时长，此后再次调用将返回 0。
+func (s *Stopwatch) Lap() time.Duration {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    now := s.now()
+    d := now.Sub(s.lap)
+    s.lap = now
+    s.laps = append(s.laps, d)
+    return d
+}
+
+// Laps 返回所有已记录的分段时长的副本。
+func (s *Stopwatch) Laps() []time.Duration {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return append([]time.Duration(nil), s.laps...)
+}
+
+// Reset 清空所有分段记录，并以当前时刻重新启动秒表，已停止的秒表将恢复计时。
+func (s *Stopwatch) Reset() {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    now := time.Now()
+    s.start, s.lap, s.stop, s.laps = now, now, time.Time{}, nil
+}
+
+// Stop 停止秒表并返回自启动以来经过的时长，重复调用将返回首次停止时冻结的时长。
+func (s *Stopwatch) Stop() time.Duration {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if s.stop.IsZero() {
+        s.stop = time.Now()
+    }
+    return s.stop.Sub(s.start)
+}
@@ -0,0 +1,29 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestStopwatch(t *testing.T) {
+    sw := chrono.StartStopwatch()
+    time.Sleep(10 * time.Millisecond)
+    if lap := sw.Lap(); lap < 10*time.Millisecond {
+        t.Errorf("Lap() = %v, want >= 10ms", lap)
+    }
+
+    stopped := sw.Stop()
+    time.Sleep(10 * time.Millisecond)
+    if elapsed := sw.Elapsed(); elapsed != stopped {
+        t.Errorf("Elapsed() = %v after Stop, want frozen %v", elapsed, stopped)
+    }
+    if laps := sw.Laps(); len(laps) != 1 {
+        t.Errorf("Laps() = %v, want 1 lap", laps)
+    }
+
+    sw.Reset()
+    if elapsed := sw.Elapsed(); elapsed >= stopped || len(sw.Laps()) != 0 {
+        t.Errorf("Elapsed() = %v after Reset, want < %v", elapsed, stopped)
+    }
+}
@@ -0,0 +1,85 @@
+package chrono
+
+import (
+    "sync"
+    "time"
+)
+
+// Ticker 是一个按照时间单位边界对齐触发的打点器。
+//
+// 与 time.Ticker 按固定间隔触发不同，Ticker 的每一次触发都落在时间单位的起始点上，例如每分钟的第 0 秒。
+// 通过 C 方法获取的通道将在每次触发时收到对应的边界时间。
+//
+// 关键行为说明：
+//  - 与 time.Ticker 一致，通道的缓冲区大小为 1，接收方处理过慢时多余的触发将被丢弃
+//  - 停止后通道不会被关闭，以避免接收方误读到零值
+type Ticker interface {
+    // C 返回接收触发时间的通道，通道中的时间为本次触发所对应的边界时间
+    C() <-chan time.Time
+
+    // Stop 停止打点器，重复调用是安全的
+    Stop()
+}
+
+// NewAlignedTicker 创建一个与时间单位边界对齐的打点器。
+//
+// 参数 unit 指定了对齐的时间单位，首次触发将落在 NextStartOf 所计算的下一个边界上，此后每次触发都保持对齐。
+// 例如在 10:03:07 使用 UnitMinute 创建时，将依次在 10:04:00、10:05:00 ... 触发。
+//
+// 关键行为说明：
+//  - 每个下一次的边界都基于当前的墙上时间重新计算，而非上一次触发时间加上固定间隔，因此调度漂移不会累积
+//  - 当接收方的处理耗时超过一个时间单位时，错过的边界将被跳过，后续触发依旧保持对齐
+//  - 对于定义外的单位，函数会抛出异常
+//
+// 使用建议：
+//  - 适用于日志切割、整点统计等需要与墙上时间对齐的场景
+//  - 不再使用时应调用 Stop 释放资源
+func NewAlignedTicker(unit Unit) Ticker {
+    if _, err := StartOfE(time.Time{}, unit); err != nil {
+        panic(err)
+    }
+    t := &alignedTicker{
+        unit: unit,
+        c:    make(chan time.Time, 1),
+        stop: make(chan struct{}),
+    }
+    go t.run()
+    return t
+}
+
+type alignedTicker struct {
+    unit Unit
+    c    chan time.Time
+    stop chan struct{}
+    once sync.Once
+}
+
+func (t *alignedTicker) C() <-chan time.Time {
+    return t.c
+}
+
+func (t *alignedTicker) Stop() {
+    t.once.Do(func() {
+        close(t.stop)
+    })
+}
+
+func (t *alignedTicker) run() {
+    next := NextStartOf(time.Now(), t.unit)
+    timer := time.NewTimer(time.Until(next))
+    defer timer.Stop()
+    for {
+        select {
+        case <-t.stop:
+            return
+        case <-timer.C:
+            select {
+            case t.c <- next:
+            default:
+            }
+            // 基于墙上时间重新计算下一个边界，避免漂移累积，同时确保不会重复触发同一边界
+            next = NextStartOf(Max(time.Now(), next), t.unit)
+            timer.Reset(time.Until(next))
+        }
+    }
+}
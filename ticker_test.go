@@ -0,0 +1,27 @@
+package chrono_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "testing"
+    "time"
+)
+
+func TestNewAlignedTicker(t *testing.T) {
+    ticker := chrono.NewAlignedTicker(chrono.UnitSecond)
+    defer ticker.Stop()
+
+    var previous time.Time
+    for i := 0; i < 2; i++ {
+        tick := <-ticker.C()
+        if !tick.Equal(tick.Truncate(time.Second)) {
+            t.Errorf("tick %v is not aligned to second", tick)
+        }
+        if !previous.IsZero() && !tick.After(previous) {
+            t.Errorf("tick %v is not after previous tick %v", tick, previous)
+        }
+        previous = tick
+
+        // 模拟耗时较长的处理
+        time.Sleep(1200 * time.Millisecond)
+    }
+}
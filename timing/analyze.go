@@ -0,0 +1,116 @@
+package timing
+
+import (
+    "time"
+)
+
+var (
+    // analyzeTicks 是 Analyze 推荐刻度时的候选值，单位为毫秒
+    analyzeTicks = []int64{1000, 500, 100, 50, 10, 5, 1}
+    // analyzeSizes 是 Analyze 推荐大小时的候选值，按升序排列
+    analyzeSizes = []int64{16, 32, 64, 128, 256, 512, 1024, 2048, 4096}
+)
+
+// TuningReport 是 Analyze 针对给定的延迟分布所给出的时间轮配置建议及预测结果
+type TuningReport struct {
+    Tick          time.Duration // 推荐的时间轮刻度
+    Size          int           // 推荐的时间轮大小
+    Levels        int           // 预计的时间轮层级数量，包含主时间轮，值为 1 时表示不会产生溢出轮
+    LevelCounts   []int         // 各层级中所容纳的样本数量，下标 0 表示主时间轮
+    Immediate     int           // 不足一个刻度而将被立即执行的样本数量
+    WastedBuckets int           // 所有层级中从未被样本使用的桶数量
+}
+
+// Configurator 返回一个应用了该报告中推荐的刻度与大小的配置器，可直接用于 New
+func (r TuningReport) Configurator() Configurator {
+    return ConfiguratorFN(func(config Configuration) {
+        config.WithTick(r.Tick).WithSize(r.Size)
+    })
+}
+
+// Analyze 根据一组具有代表性的调度延迟样本，推荐时间轮的刻度与大小组合。
+//
+// 该函数是一个纯函数，不依赖于任何运行中的时间轮。它使用与时间轮添加计时器时相同的分类方式，
+// 即以 current+tick 及 current+interval 作为边界，预测每个样本所处的层级。
+//
+// 推荐策略如下：
+//  - 刻度取不超过最小正样本十分之一的候选值（最小为 1ms），以保证调度精度
+//  - 在该刻度下，选择能够使层级数量最少的最小大小，以减少溢出轮层级的同时避免浪费过多的桶
+//
+// 关键行为说明：
+//  - 零值或负值样本将被视为立即执行，不参与刻度的推荐
+//  - 当没有正样本时，将返回与 NewConfig 一致的默认配置
+//
+// 使用建议：
+// 样本应尽可能覆盖实际业务中的延迟分布，例如同时包含短时的超时任务及长时的定时任务。
+func Analyze(sampleDurations []time.Duration) TuningReport {
+    var minMs int64
+    for _, d := range sampleDurations {
+        if ms := int64(d / time.Millisecond); ms > 0 && (minMs == 0 || ms < minMs) {
+            minMs = ms
+        }
+    }
+
+    config := NewConfig()
+    tick, size := config.FetchTick(), config.FetchSize()
+    if minMs > 0 {
+        for _, candidate := range analyzeTicks {
+            if candidate*10 <= minMs || candidate == 1 {
+                tick = candidate
+                break
+            }
+        }
+
+        var best int
+        for _, candidate := range analyzeSizes {
+            if levels := analyzeLevels(sampleDurations, tick, candidate); best == 0 || levels < best {
+                best, size = levels, candidate
+            }
+        }
+    }
+
+    report := TuningReport{
+        Tick: time.Duration(tick) * time.Millisecond,
+        Size: int(size),
+    }
+    used := make(map[[2]int64]struct{})
+    for _, d := range sampleDurations {
+        level, index := analyzeClassify(int64(d/time.Millisecond), tick, size)
+        if level < 0 {
+            report.Immediate++
+            continue
+        }
+        for len(report.LevelCounts) <= level {
+            report.LevelCounts = append(report.LevelCounts, 0)
+        }
+        report.LevelCounts[level]++
+        used[[2]int64{int64(level), index}] = struct{}{}
+    }
+    report.Levels = max(len(report.LevelCounts), 1)
+    report.WastedBuckets = report.Levels*int(size) - len(used)
+    return report
+}
+
+// analyzeLevels 返回在给定刻度与大小下容纳所有样本所需的层级数量
+func analyzeLevels(samples []time.Duration, tick, size int64) int {
+    var levels int
+    for _, d := range samples {
+        if level, _ := analyzeClassify(int64(d/time.Millisecond), tick, size); level+1 > levels {
+            levels = level + 1
+        }
+    }
+    return levels
+}
+
+// analyzeClassify 以当前时间为 0，按照 wheelInternalImpl.add 的方式对毫秒级过期时间进行分类，
+// 返回其所在的层级及桶下标，当其将被立即执行时层级为 -1
+func analyzeClassify(expiration, tick, size int64) (level int, index int64) {
+    if expiration < tick {
+        return -1, 0
+    }
+    for expiration >= tick*size {
+        tick *= size
+        level++
+    }
+    return level, expiration / tick % size
+}
@@ -0,0 +1,29 @@
+package timing_test
+
+import (
+    "github.com/kercylan98/chrono/timing"
+    "testing"
+    "time"
+)
+
+func TestAnalyze(t *testing.T) {
+    // 双峰分布：大量 50ms ~ 200ms 的超时任务与 1h ~ 2h 的定时任务
+    var samples []time.Duration
+    for i := 0; i < 100; i++ {
+        samples = append(samples, 50*time.Millisecond+time.Duration(i)*1500*time.Microsecond)
+        samples = append(samples, time.Hour+time.Duration(i)*36*time.Second)
+    }
+    samples = append(samples, 0)
+
+    report := timing.Analyze(samples)
+    if report.Tick != 5*time.Millisecond || report.Size != 2048 || report.Levels != 2 {
+        t.Errorf("Analyze() = tick %v, size %d, levels %d, want tick 5ms, size 2048, levels 2", report.Tick, report.Size, report.Levels)
+    }
+    if report.Immediate != 1 || report.LevelCounts[0] != 100 || report.LevelCounts[1] != 100 {
+        t.Errorf("Analyze() = immediate %d, level counts %v", report.Immediate, report.LevelCounts)
+    }
+
+    if report = timing.Analyze(nil); report.Levels != 1 || report.Tick != time.Millisecond {
+        t.Errorf("Analyze(nil) = %+v", report)
+    }
+}
@@ -11,7 +11,10 @@ var (
 	_ bucket = (*bucketImpl)(nil)
 )
 
-func newBucket(wheel Wheel) bucket {
+func newBucket(wheel Wheel, storage BucketStorage) bucket {
+	if storage == BucketStorageSlice {
+		return newSliceBucket(wheel)
+	}
 	return &bucketImpl{
 		wheel:  wheel,
 		timers: list.New(),
@@ -34,7 +37,10 @@ type bucket interface {
 	// remove 从计时桶中移除一个计时器，如果计时器不在计时桶中则返回 false
 	remove(Timer) bool
 
-	// flush 清空计时桶中的所有计时器，并将这些计时器重新插入到时间轮中
+	// collect 将计时桶中过期时间不晚于 deadline 的计时器追加到 dst 中并返回，该过程不会移除计时器
+	collect(deadline int64, dst []Timer) []Timer
+
+	// flush 清空计时桶中的所有计时器，并将这些计时器按插入顺序重新插入到时间轮中
 	flush(adder func(Timer))
 }
 
@@ -61,44 +67,61 @@ func (b *bucketImpl) setExpiration(expiration int64) bool {
 
 func (b *bucketImpl) add(timer Timer) {
 	b.rw.Lock()
-	e := b.timers.PushBack(timer)
-	b.rw.Unlock()
-
-	timer.setBucket(b, e)
+	defer b.rw.Unlock()
+	timer.setBucket(b, b.timers.PushBack(timer))
 }
 
 func (b *bucketImpl) remove(t Timer) bool {
+	// 计时器所在的桶需要在持有锁的情况下进行确认，避免与并发的 flush 重复移除
+	b.rw.Lock()
 	if t.getBucket() != b {
+		b.rw.Unlock()
 		return false
 	}
-
-	b.rw.Lock()
 	b.timers.Remove(t.getElement())
-	defer b.rw.Unlock()
-
 	t.setBucket(nil, nil)
+	b.rw.Unlock()
+
 	b.wheel.refreshDelayQueue()
 	return true
 }
 
+func (b *bucketImpl) collect(deadline int64, dst []Timer) []Timer {
+	b.rw.RLock()
+	defer b.rw.RUnlock()
+
+	for e := b.timers.Front(); e != nil; e = e.Next() {
+		if t := e.Value.(Timer); t.getExpiration() <= deadline {
+			dst = append(dst, t)
+		}
+	}
+	return dst
+}
+
 func (b *bucketImpl) flush(adder func(Timer)) {
 	// 该函数会在延迟队列的回调中被调用，该调用是异步的，需要确保线程安全
 	b.rw.Lock()
-	defer b.rw.Unlock()
-
+	timers := make([]Timer, 0, b.timers.Len())
 	for e := b.timers.Front(); e != nil; {
 		next := e.Next()
 
 		t := e.Value.(Timer)
 		b.timers.Remove(e)
 		t.setBucket(nil, nil)
-
-		// 添加到时间轮中时，如果任务时间已经到达，将被执行
-		go adder(t)
+		timers = append(timers, t)
 
 		e = next
 	}
 
 	b.setExpiration(-1)
+	b.rw.Unlock()
 	b.wheel.refreshDelayQueue()
+
+	// 添加到时间轮中时，如果任务时间已经到达，将被执行
+	//  - 在同一协程中按插入顺序依次添加，确保相同过期时间的计时器按 FIFO 顺序交付执行器
+	go func() {
+		for _, t := range timers {
+			adder(t)
+		}
+	}()
 }
@@ -0,0 +1,118 @@
+package timing
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	_ bucket = (*sliceBucket)(nil)
+)
+
+// BucketStorage 定义了时间轮中计时桶存储计时器所使用的数据结构
+type BucketStorage int
+
+const (
+	BucketStorageList  BucketStorage = iota // BucketStorageList 表示使用双向链表存储计时器，移除计时器的时间复杂度为 O(1)，这是默认的存储方式
+	BucketStorageSlice                      // BucketStorageSlice 表示使用切片存储计时器，具有更好的插入吞吐量及缓存局部性，但移除计时器的时间复杂度为 O(n)
+)
+
+func newSliceBucket(wheel Wheel) bucket {
+	return &sliceBucket{
+		wheel: wheel,
+	}
+}
+
+// sliceBucket 是基于切片的计时桶，适用于大量计时器在同一刻度到期且极少被提前停止的场景
+//  - 被移除的计时器所在的位置将被置为 nil，在 flush 时统一跳过，以保证其余计时器的插入顺序
+type sliceBucket struct {
+	expiration atomic.Int64
+	timers     []Timer
+	size       int // 计时桶中未被移除的计时器数量
+	rw         sync.RWMutex
+	wheel      Wheel // 所属时间轮
+}
+
+func (b *sliceBucket) Size() int {
+	b.rw.RLock()
+	defer b.rw.RUnlock()
+	return b.size
+}
+
+func (b *sliceBucket) getExpiration() int64 {
+	return b.expiration.Load()
+}
+
+func (b *sliceBucket) setExpiration(expiration int64) bool {
+	return b.expiration.Swap(expiration) != expiration
+}
+
+func (b *sliceBucket) add(timer Timer) {
+	b.rw.Lock()
+	defer b.rw.Unlock()
+	b.timers = append(b.timers, timer)
+	b.size++
+	timer.setBucket(b, nil)
+}
+
+func (b *sliceBucket) remove(t Timer) bool {
+	// 计时器所在的桶需要在持有锁的情况下进行确认，避免与并发的 flush 重复移除
+	b.rw.Lock()
+	if t.getBucket() != b {
+		b.rw.Unlock()
+		return false
+	}
+	for i, timer := range b.timers {
+		if timer == t {
+			b.timers[i] = nil
+			b.size--
+			break
+		}
+	}
+	if b.size == 0 {
+		b.timers = b.timers[:0]
+	}
+	t.setBucket(nil, nil)
+	b.rw.Unlock()
+
+	b.wheel.refreshDelayQueue()
+	return true
+}
+
+func (b *sliceBucket) collect(deadline int64, dst []Timer) []Timer {
+	b.rw.RLock()
+	defer b.rw.RUnlock()
+
+	for _, t := range b.timers {
+		if t != nil && t.getExpiration() <= deadline {
+			dst = append(dst, t)
+		}
+	}
+	return dst
+}
+
+func (b *sliceBucket) flush(adder func(Timer)) {
+	// 该函数会在延迟队列的回调中被调用，该调用是异步的，需要确保线程安全
+	b.rw.Lock()
+	timers := b.timers[:0]
+	for _, t := range b.timers {
+		if t != nil {
+			t.setBucket(nil, nil)
+			timers = append(timers, t)
+		}
+	}
+	// 原切片将交由 adder 所在的协程使用，因此不能复用
+	b.timers, b.size = nil, 0
+
+	b.setExpiration(-1)
+	b.rw.Unlock()
+	b.wheel.refreshDelayQueue()
+
+	// 添加到时间轮中时，如果任务时间已经到达，将被执行
+	//  - 在同一协程中按插入顺序依次添加，确保相同过期时间的计时器按 FIFO 顺序交付执行器
+	go func() {
+		for _, t := range timers {
+			adder(t)
+		}
+	}()
+}
@@ -0,0 +1,77 @@
+package timing_test
+
+import (
+    "github.com/kercylan98/chrono/timing"
+    "sync"
+    "testing"
+    "time"
+)
+
+func withBucketStorage(storage timing.BucketStorage) timing.Configurator {
+    return timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithBucketStorage(storage)
+    })
+}
+
+func TestBucketStorage(t *testing.T) {
+    for _, storage := range []timing.BucketStorage{timing.BucketStorageList, timing.BucketStorageSlice} {
+        tw := timing.New(withBucketStorage(storage))
+
+        var mu sync.Mutex
+        var order []int
+        var wg sync.WaitGroup
+        var timers []timing.Timer
+        for i := 0; i < 100; i++ {
+            wg.Add(1)
+            timers = append(timers, tw.After(50*time.Millisecond, timing.TaskFN(func() {
+                mu.Lock()
+                order = append(order, i)
+                mu.Unlock()
+                wg.Done()
+            })))
+        }
+        // 50ms 超出了默认时间轮的区间，因此计时器将首先进入溢出轮，停止奇数位置的计时器后，剩余的计时器仍需按添加顺序执行
+        for i := 1; i < len(timers); i += 2 {
+            if timers[i].Stop() {
+                wg.Done()
+            }
+        }
+        wg.Wait()
+
+        if len(order) != 50 {
+            t.Fatalf("storage %d: executed %d timers, want 50", storage, len(order))
+        }
+        for i, v := range order {
+            if v != i*2 {
+                t.Fatalf("storage %d: execution order = %v, want even indexes in insertion order", storage, order)
+            }
+        }
+    }
+}
+
+// BenchmarkBucketStorage 衡量 500k 个计时器在同一刻度到期时的插入与到期处理耗时
+func BenchmarkBucketStorage(b *testing.B) {
+    const timers = 500_000
+    for _, bm := range []struct {
+        name    string
+        storage timing.BucketStorage
+    }{
+        {"List", timing.BucketStorageList},
+        {"Slice", timing.BucketStorageSlice},
+    } {
+        b.Run(bm.name, func(b *testing.B) {
+            b.ReportAllocs()
+            for i := 0; i < b.N; i++ {
+                tw := timing.New(withBucketStorage(bm.storage))
+                var wg sync.WaitGroup
+                wg.Add(timers)
+                task := timing.TaskFN(wg.Done)
+                at := time.Now().Add(10 * time.Millisecond)
+                for j := 0; j < timers; j++ {
+                    tw.At(at, task)
+                }
+                wg.Wait()
+            }
+        })
+    }
+}
@@ -0,0 +1,160 @@
+package timing
+
+import (
+    "github.com/kercylan98/chrono"
+    "sync"
+    "time"
+)
+
+var (
+    _            Clock           = (*ManualClock)(nil)
+    _            cancelableClock = (*ManualClock)(nil)
+    _            wallConverter   = (*monotonicClock)(nil)
+    defaultClock Clock           = realClock{}
+)
+
+// Clock 是时间轮所使用的时间源，时间轮的推进、延迟队列的等待以及 After、Loop、Cron 等方法对当前时间的读取都将通过同一个 Clock 进行。
+//
+// 默认使用系统时钟，通过 WithClock 可以替换为自定义的实现，例如用于确定性测试及仿真的 ManualClock。
+//
+// Clock 在 chrono.Clock 的基础上增加了定时等待的能力，因此任意 timing.Clock 同样可以作为 chrono.Clock 使用。
+//
+// 关键行为说明：
+//  - 实现必须是并发安全的
+type Clock interface {
+    chrono.Clock
+
+    // After 返回一个在经过 d 后可读的通道，当 d 小于等于 0 时通道应当立即可读
+    After(d time.Duration) <-chan time.Time
+}
+
+// realClock 是基于系统时钟的 Clock 实现
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+    return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+    return time.After(d)
+}
+
+// cancelableClock 是可以取消等待的 Clock，延迟队列在因队首元素变化而放弃等待时将通过返回的函数取消等待，
+// 以避免 ManualClock 等需要自行记录等待者的实现中被放弃的等待者持续堆积
+type cancelableClock interface {
+    afterCancel(d time.Duration) (<-chan time.Time, func())
+}
+
+// wallConverter 是时间基准可能与墙上时间不一致的 Clock，At 将通过 fromWall 将墙上时间换算至其时间基准
+type wallConverter interface {
+    fromWall(t time.Time) time.Time
+}
+
+func newMonotonicClock() *monotonicClock {
+    return &monotonicClock{start: time.Now(), wall: time.Now}
+}
+
+// monotonicClock 是基于单调时钟的 Clock 实现，其时间基准为创建时的墙上时间加上此后经过的单调时长，不受系统时钟跳变的影响
+type monotonicClock struct {
+    start time.Time        // 创建时刻，携带单调时钟读数
+    wall  func() time.Time // 墙上时间的来源，仅用于 fromWall 换算
+}
+
+func (c *monotonicClock) Now() time.Time {
+    return c.start.Add(time.Since(c.start))
+}
+
+func (c *monotonicClock) After(d time.Duration) <-chan time.Time {
+    return time.After(d)
+}
+
+func (c *monotonicClock) fromWall(t time.Time) time.Time {
+    return c.Now().Add(t.Sub(c.wall()))
+}
+
+// NewManualClock 创建一个以 start 作为初始时间的手动时钟。
+//
+// 手动时钟的时间仅在调用 Advance 或 Set 时发生变化，适用于确定性测试及仿真等场景。
+func NewManualClock(start time.Time) *ManualClock {
+    return &ManualClock{now: start}
+}
+
+// ManualClock 是一个手动推进的 Clock 实现，通过 NewManualClock 创建。
+//
+// 关键行为说明：
+//  - 通过 After 创建的等待者将被保留至时钟推进至其截止时间，在未推进时钟的情况下反复调用 After 将使等待者持续增长
+//  - 作为时间轮的时间源时，被延迟队列放弃的等待将被及时移除，因此不会因计时器的反复添加而堆积
+type ManualClock struct {
+    mu      sync.Mutex
+    now     time.Time
+    waiters []manualClockWaiter
+}
+
+type manualClockWaiter struct {
+    deadline time.Time
+    c        chan time.Time
+}
+
+func (c *ManualClock) Now() time.Time {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.now
+}
+
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+    ch, _ := c.afterCancel(d)
+    return ch
+}
+
+func (c *ManualClock) afterCancel(d time.Duration) (<-chan time.Time, func()) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    ch := make(chan time.Time, 1)
+    if d <= 0 {
+        ch <- c.now
+        return ch, func() {}
+    }
+    c.waiters = append(c.waiters, manualClockWaiter{deadline: c.now.Add(d), c: ch})
+    return ch, func() {
+        c.cancel(ch)
+    }
+}
+
+// cancel 移除通道为 ch 的等待者，等待者已被唤醒时不产生任何影响
+func (c *ManualClock) cancel(ch chan time.Time) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for i, w := range c.waiters {
+        if w.c == ch {
+            c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+            return
+        }
+    }
+}
+
+// Advance 将时钟向后推进 d，并唤醒所有因此到期的等待者
+func (c *ManualClock) Advance(d time.Duration) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.set(c.now.Add(d))
+}
+
+// Set 将时钟设置为 t，并唤醒所有因此到期的等待者
+func (c *ManualClock) Set(t time.Time) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.set(t)
+}
+
+func (c *ManualClock) set(t time.Time) {
+    c.now = t
+    waiters := c.waiters[:0]
+    for _, w := range c.waiters {
+        if w.deadline.After(t) {
+            waiters = append(waiters, w)
+            continue
+        }
+        w.c <- t
+    }
+    c.waiters = waiters
+}
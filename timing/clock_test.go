@@ -0,0 +1,143 @@
+package timing_test
+
+import (
+    "github.com/kercylan98/chrono"
+    "github.com/kercylan98/chrono/timing"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestManualClock(t *testing.T) {
+    clock := timing.NewManualClock(time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local))
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(clock)
+    }))
+
+    done := make(chan struct{})
+    tw.After(10*time.Millisecond, timing.TaskFN(func() {
+        close(done)
+    }))
+
+    clock.Advance(9 * time.Millisecond)
+    select {
+    case <-done:
+        t.Fatalf("task fired before the clock advanced 10ms")
+    case <-time.After(50 * time.Millisecond):
+    }
+
+    clock.Advance(time.Millisecond)
+    select {
+    case <-done:
+    case <-time.After(time.Second):
+        t.Fatalf("task did not fire after the clock advanced 10ms")
+    }
+}
+
+func TestClock_IsChronoClock(t *testing.T) {
+    var clock timing.Clock = timing.NewManualClock(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC))
+    var shared chrono.Clock = clock
+    if !shared.Now().Equal(clock.Now()) {
+        t.Errorf("chrono.Clock.Now() = %v, want %v", shared.Now(), clock.Now())
+    }
+}
+
+func TestMonotonicClock(t *testing.T) {
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithMonotonicClock()
+    }))
+
+    var tests = []struct {
+        name     string
+        schedule func(task timing.Task)
+    }{
+        {"After", func(task timing.Task) { tw.After(50*time.Millisecond, task) }},
+        {"At", func(task timing.Task) { tw.At(time.Now().Add(50*time.Millisecond), task) }},
+        {"AtWithoutMonotonicReading", func(task timing.Task) { tw.At(time.Now().Add(50*time.Millisecond).Round(0), task) }},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            start := time.Now()
+            done := make(chan time.Duration, 1)
+            tt.schedule(timing.TaskFN(func() {
+                done <- time.Since(start)
+            }))
+            select {
+            case elapsed := <-done:
+                if elapsed < 40*time.Millisecond {
+                    t.Errorf("task fired after %v, want about 50ms", elapsed)
+                }
+            case <-time.After(time.Second):
+                t.Fatalf("task did not fire")
+            }
+        })
+    }
+}
+
+func TestMonotonicClock_WallClockJump(t *testing.T) {
+    const delay = 50 * time.Millisecond
+    var tests = []struct {
+        name   string
+        before time.Duration // 调度前墙上时间的跳变
+        after  time.Duration // 调度后墙上时间的跳变
+    }{
+        {"ForwardBeforeAt", time.Hour, 0},
+        {"BackwardBeforeAt", -time.Hour, 0},
+        {"ForwardAfterAt", 0, time.Hour},
+        {"BackwardAfterAt", 0, -time.Hour},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            // 墙上时间由固定的起点加上经过的时长及跳变量组成，不携带单调时钟读数
+            var jump atomic.Int64
+            origin, start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), time.Now()
+            wall := func() time.Time {
+                return origin.Add(time.Since(start) + time.Duration(jump.Load()))
+            }
+            tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+                config.WithClock(timing.NewMonotonicClockWithWall(wall))
+            }))
+
+            jump.Add(int64(tt.before))
+            scheduled := time.Now()
+            done := make(chan time.Duration, 1)
+            tw.At(wall().Add(delay), timing.TaskFN(func() {
+                done <- time.Since(scheduled)
+            }))
+            jump.Add(int64(tt.after))
+
+            // 执行时刻应当跟随单调时间，既不会因回拨而推迟，也不会因前跳而提前
+            select {
+            case elapsed := <-done:
+                if elapsed < delay-10*time.Millisecond {
+                    t.Errorf("task fired after %v, want about %v", elapsed, delay)
+                }
+            case <-time.After(time.Second):
+                t.Fatalf("task did not fire within 1s, want about %v", delay)
+            }
+        })
+    }
+}
+
+func TestManualClock_AbandonedWaiters(t *testing.T) {
+    clock := timing.NewManualClock(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC))
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(clock)
+    }))
+
+    // 每个新的计时器都早于此前的计时器，延迟队列将反复放弃旧的等待并重新等待
+    for i := 100; i > 0; i-- {
+        tw.After(time.Duration(i)*time.Second, timing.TaskFN(func() {}))
+        time.Sleep(time.Millisecond)
+    }
+
+    deadline := time.Now().Add(time.Second)
+    for timing.ManualClockWaiters(clock) > 1 && time.Now().Before(deadline) {
+        time.Sleep(time.Millisecond)
+    }
+    if n := timing.ManualClockWaiters(clock); n > 1 {
+        t.Errorf("ManualClock kept %d waiters, want at most 1 since abandoned waits are cancelled", n)
+    }
+}
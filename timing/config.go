@@ -1,7 +1,10 @@
 package timing
 
 import (
+    "fmt"
     "github.com/kercylan98/options"
+    "runtime/debug"
+    "sync/atomic"
     "time"
 )
 
@@ -10,6 +13,13 @@ var (
     defaultExecutor               = ExecutorFN(func(task func()) {
         task()
     })
+    defaultPanicHandler = func(err any) {
+        fmt.Println(err)
+        debug.PrintStack()
+    }
+    defaultErrorHandler = func(err error) {
+        fmt.Println(err)
+    }
 )
 
 // NewConfig 创建一个用于 Wheel 的默认配置器
@@ -17,8 +27,12 @@ func NewConfig() Configuration {
     c := &configuration{
         tick:     1,
         size:     20,
-        executor: defaultExecutor,
+        panic:    defaultPanicHandler,
+        errors:   defaultErrorHandler,
+        location: time.Local,
+        clock:    defaultClock,
     }
+    c.setExecutor(defaultExecutor)
     c.LogicOptions = options.NewLogicOptions[OptionsFetcher, Options](c, c)
     return c
 }
@@ -50,11 +64,100 @@ type Options interface {
     // withTick 内部设置时间轮的刻度，单位为毫秒。该函数不进行换算
     withTick(tick int64) Configuration
 
+    // WithName 设置时间轮的名称，用于在日志及指标中区分同一进程中的多个时间轮
+    //  - 名称将体现在 Wheel.Stats 中，任务发生 panic 时记录的信息同样会携带该名称
+    //  - 溢出轮将继承该名称，并在任务发生 panic 时以 "名称#层级" 的形式标识
+    //  - 当任务以 error 类型的值 panic 时，重新抛出的错误将包装原始错误，可以通过 errors.Is 或 errors.As 取得
+    WithName(name string) Configuration
+
+    // withLevel 内部设置时间轮的层级，顶层时间轮为 0，溢出轮依次递增
+    withLevel(level int) Configuration
+
     // WithSize 设置时间轮的大小
     WithSize(size int) Configuration
 
+    // WithQueueCapacity 设置延迟队列的初始容量，默认为时间轮的大小
+    //  - 延迟队列中的元素为各层时间轮中等待到期的桶，当同时挂载的计时器跨越多层时间轮时，可以通过该选项预先分配容量以减少扩容
+    //  - 延迟队列的容量在增长后不会收缩
+    //  - 当 capacity 小于等于 0 时将使用时间轮的大小
+    WithQueueCapacity(capacity int) Configuration
+
+    // WithSpinThreshold 设置延迟队列等待队首桶到期的休眠阈值，默认为 0，即不启用
+    //  - 当距离队首桶到期的时长不超过 threshold 时，延迟队列将直接通过 time.Sleep 休眠，而非为每次等待创建新的定时器通道，
+    //    适用于大量计时器在 1~2 毫秒内密集到期的场景，以减少等待过程中的内存分配
+    //  - 休眠期间无法响应新加入的更早到期的桶，其执行至多将被推迟 threshold
+    //  - 休眠基于真实时间，与 WithClock 设置的时间源无关，因此不应与 ManualClock 同时使用
+    WithSpinThreshold(threshold time.Duration) Configuration
+
+    // WithQueueTrace 设置延迟队列的跟踪函数，用于诊断计时器延迟执行等问题，默认为 nil，即不进行跟踪
+    //  - 延迟队列将在休眠、唤醒、处理到期的计时桶及刷新时调用 trace，事件的含义详见 QueueEventKind
+    //  - trace 在延迟队列的处理协程及添加计时器的协程中被同步且并发地调用，其耗时将直接推迟后续计时桶的处理，因此跟踪本身可能影响调度的时间
+    //  - 未设置时仅存在一次空值判断，不会产生额外的开销
+    //  - MockWheel 不使用延迟队列，因此不会产生任何事件
+    WithQueueTrace(trace func(event QueueEvent)) Configuration
+
+    // WithOverflowLevels 设置在构建时预先创建的溢出轮层数，默认为 0，即在首个超出当前时间轮范围的计时器加入时才按需创建
+    //  - 按需创建溢出轮发生在持有锁的调度路径上，预先创建可以将这部分开销转移至构建时，使远期计时器的调度延迟更加稳定
+    //  - 每一层溢出轮的范围是上一层的 size 倍，例如默认配置下第 1 至 3 层分别覆盖 400ms、8s 及 160s
+    //  - 超出预先创建的层数时，更高层的溢出轮仍将按需创建
+    WithOverflowLevels(levels int) Configuration
+
     // WithExecutor 设置时间轮的执行器
+    //  - 相同过期时间的任务仅在同步或单工作协程的执行器下保证按添加顺序执行
     WithExecutor(executor Executor) Configuration
+
+    // WithExecutorQueue 为时间轮的执行器增加一个容量为 capacity 的有界队列，队列已满时按照 policy 进行处理
+    //  - 队列将在构建时间轮时包装最终设置的执行器，因此与 WithExecutor 的调用顺序无关
+    //  - 被丢弃的任务数量可以通过 Wheel.Stats 获取，详见 NewQueueExecutor
+    //  - 队列的工作协程在时间轮的整个生命周期内运行，如需在不再使用时将其关闭，请通过 NewQueueExecutor 创建执行器并传入 WithExecutor，以便调用 QueueExecutor.Close
+    WithExecutorQueue(capacity int, policy OverflowPolicy) Configuration
+
+    // WithBucketStorage 设置计时桶存储计时器所使用的数据结构，默认为 BucketStorageList
+    //  - 当大量计时器在同一刻度到期且极少被提前停止时，BucketStorageSlice 能够减少内存分配并提升插入及到期处理的吞吐量
+    //  - BucketStorageSlice 下停止计时器需要遍历其所在的桶，频繁停止计时器的场景应当使用默认的 BucketStorageList
+    //  - 溢出轮将继承该设置
+    WithBucketStorage(storage BucketStorage) Configuration
+
+    // WithLocation 设置时间轮计算 cron 表达式及日历调度时所使用的默认时区，默认为 time.Local
+    //  - 当 location 为 nil 时将使用 time.Local
+    //  - 在夏令时切换时，被跳过的墙上时间（例如春季拨快的 02:30）将按照 WithDSTPolicy 设置的策略处理，
+    //    重复出现的墙上时间（例如秋季回拨的 01:30）仅会触发一次
+    WithLocation(location *time.Location) Configuration
+
+    // WithDSTPolicy 设置 Cron 表达式命中因夏令时切换而不存在的墙上时间时的处理策略，默认为 DSTPolicyFireNext
+    //  - 该策略仅作用于 Wheel.Cron 及 Named.Cron，日历调度 CalendarSchedule 始终在当天第一个有效的时刻执行
+    WithDSTPolicy(policy DSTPolicy) Configuration
+
+    // WithClock 设置时间轮的时间源，默认为系统时钟
+    //  - 时间轮的推进、延迟队列的等待以及 After、Loop、Cron 等方法对当前时间的读取都将使用该时间源
+    //  - 当 clock 为 nil 时将使用系统时钟
+    WithClock(clock Clock) Configuration
+
+    // WithMonotonicClock 设置时间轮使用基于单调时钟的系统时钟作为时间源，以抵御 NTP 校时、虚拟机挂起恢复等导致的系统时钟跳变
+    //  - 时间轮的时间基准为创建时的墙上时间加上此后经过的单调时长，因此即便系统时钟被回拨一小时，After(10 * time.Minute) 仍将在约 10 分钟后执行
+    //  - At 将以调用时的墙上时间把 t 换算为相对延迟，此后的跳变同样不会影响执行时刻，但这意味着任务将不再对齐到跳变后的墙上时间
+    //  - Cron、CalendarSchedule 等绝对时间的调度将基于时间轮的时间基准进行计算，系统时钟跳变后将与实际的墙上时间存在偏差，
+    //    对于必须对齐墙上时间的任务，应当避免使用该选项，或在检测到跳变后重新调度
+    //  - 该选项将覆盖 WithClock 设置的时间源，反之亦然
+    WithMonotonicClock() Configuration
+
+    // WithImmediateSync 设置延迟为零或负值的 After 及 At 任务在调用方的协程中同步执行，类似于直接调用任务函数
+    //  - 默认情况下，已经到期的任务将在新的协程中交由执行器执行，因此在 After 返回时任务可能尚未开始执行
+    //  - 设置后此类任务不经过执行器，执行过程中发生的 panic 将与默认执行器一样被捕获并记录，不会传播给调用方
+    //  - 时间轮暂停期间该选项不生效，任务将按照 Pause 的语义被暂存
+    WithImmediateSync() Configuration
+
+    // WithPanicHandler 设置时间轮内部调度过程中发生 panic 时的处理函数，默认将打印错误信息及堆栈
+    //  - 内部调度指延迟队列对到期桶的推进与分发，发生 panic 后时间轮将继续处理后续的桶，而不会永久停止触发
+    //  - 任务执行过程中发生的 panic 由执行器处理，不会交由该函数
+    //  - 当 handler 为 nil 时将使用默认的处理函数
+    WithPanicHandler(handler func(err any)) Configuration
+
+    // WithErrorHandler 设置通过 NewErrTask 创建的任务返回非 nil 错误时的处理函数，默认将打印错误信息
+    //  - 处理函数在执行任务的协程中被同步调用，应当保持轻量
+    //  - 任务执行过程中发生的 panic 由执行器处理，不会交由该函数
+    //  - 当 handler 为 nil 时将使用默认的处理函数
+    WithErrorHandler(handler func(err error)) Configuration
 }
 
 type OptionsFetcher interface {
@@ -62,14 +165,61 @@ type OptionsFetcher interface {
 
     FetchSize() int64
 
+    FetchQueueCapacity() int
+
+    FetchSpinThreshold() time.Duration
+
+    FetchQueueTrace() func(event QueueEvent)
+
+    FetchOverflowLevels() int
+
     FetchExecutor() Executor
+
+    FetchBucketStorage() BucketStorage
+
+    FetchLocation() *time.Location
+
+    FetchClock() Clock
+
+    FetchDSTPolicy() DSTPolicy
+
+    FetchName() string
+
+    FetchImmediateSync() bool
+
+    FetchPanicHandler() func(err any)
+
+    FetchErrorHandler() func(err error)
+
+    // fetchLevel 返回时间轮的层级，顶层时间轮为 0
+    fetchLevel() int
+
+    // setExecutor 原子地替换执行器，当 executor 为 nil 时将使用默认的执行器
+    setExecutor(executor Executor)
+
+    // buildExecutor 在构建顶层时间轮时以 WithExecutorQueue 设置的有界队列包装当前的执行器
+    buildExecutor()
 }
 
 type configuration struct {
     options.LogicOptions[OptionsFetcher, Options]
-    tick     int64 // 每个刻度的毫秒级时间
-    size     int64 // 每个时间轮的毫秒级间隔时间
-    executor Executor
+    tick     int64                    // 每个刻度的毫秒级时间
+    size     int64                    // 每个时间轮的毫秒级间隔时间
+    capacity int                      // 延迟队列的初始容量，小于等于 0 时使用 size
+    spin     time.Duration            // 延迟队列以休眠代替定时器通道进行等待的阈值
+    levels   int                      // 构建时预先创建的溢出轮层数
+    trace    func(event QueueEvent)   // 延迟队列的跟踪函数
+    executor atomic.Pointer[Executor] // 执行器，可以通过 Wheel.SetExecutor 在运行时原子地替换
+    queue    func(Executor) Executor  // 构建时包装执行器的有界队列，为 nil 时不进行包装
+    storage  BucketStorage            // 计时桶存储计时器所使用的数据结构
+    panic    func(err any)            // 内部调度发生 panic 时的处理函数
+    errors   func(err error)          // 任务返回错误时的处理函数
+    location *time.Location           // 计算 cron 表达式及日历调度的默认时区
+    clock    Clock                    // 时间源
+    dst      DSTPolicy                // 夏令时切换时不存在的墙上时间的处理策略
+    name     string                   // 时间轮的名称
+    level    int                      // 时间轮的层级，顶层时间轮为 0
+    sync     bool                     // 是否同步执行已经到期的 After 及 At 任务
 }
 
 func (t *configuration) WithTick(tick time.Duration) Configuration {
@@ -82,13 +232,118 @@ func (t *configuration) withTick(tick int64) Configuration {
     return t
 }
 
+func (t *configuration) WithName(name string) Configuration {
+    t.name = name
+    return t
+}
+
+func (t *configuration) withLevel(level int) Configuration {
+    t.level = level
+    return t
+}
+
 func (t *configuration) WithSize(size int) Configuration {
     t.size = int64(size)
     return t
 }
 
+func (t *configuration) WithQueueCapacity(capacity int) Configuration {
+    t.capacity = capacity
+    return t
+}
+
+func (t *configuration) WithSpinThreshold(threshold time.Duration) Configuration {
+    t.spin = threshold
+    return t
+}
+
+func (t *configuration) WithQueueTrace(trace func(event QueueEvent)) Configuration {
+    t.trace = trace
+    return t
+}
+
+func (t *configuration) WithOverflowLevels(levels int) Configuration {
+    t.levels = levels
+    return t
+}
+
 func (t *configuration) WithExecutor(executor Executor) Configuration {
-    t.executor = executor
+    t.setExecutor(executor)
+    return t
+}
+
+func (t *configuration) setExecutor(executor Executor) {
+    if executor == nil {
+        executor = defaultExecutor
+    }
+    t.executor.Store(&executor)
+}
+
+func (t *configuration) WithExecutorQueue(capacity int, policy OverflowPolicy) Configuration {
+    t.queue = func(executor Executor) Executor {
+        return NewQueueExecutor(executor, capacity, policy)
+    }
+    return t
+}
+
+func (t *configuration) buildExecutor() {
+    if t.queue == nil {
+        return
+    }
+    t.setExecutor(t.queue(t.FetchExecutor()))
+    // 仅包装一次，以同一配置构建的多个时间轮将共享同一个队列
+    t.queue = nil
+}
+
+func (t *configuration) WithBucketStorage(storage BucketStorage) Configuration {
+    t.storage = storage
+    return t
+}
+
+func (t *configuration) WithLocation(location *time.Location) Configuration {
+    if location == nil {
+        location = time.Local
+    }
+    t.location = location
+    return t
+}
+
+func (t *configuration) WithClock(clock Clock) Configuration {
+    if clock == nil {
+        clock = defaultClock
+    }
+    t.clock = clock
+    return t
+}
+
+func (t *configuration) WithMonotonicClock() Configuration {
+    t.clock = newMonotonicClock()
+    return t
+}
+
+func (t *configuration) WithDSTPolicy(policy DSTPolicy) Configuration {
+    t.dst = policy
+    return t
+}
+
+func (t *configuration) WithImmediateSync() Configuration {
+    t.sync = true
+    return t
+}
+
+func (t *configuration) WithPanicHandler(handler func(err any)) Configuration {
+    if handler == nil {
+        handler = defaultPanicHandler
+    }
+    t.panic = handler
+    return t
+}
+
+func (t *configuration) WithErrorHandler(handler func(err error)) Configuration {
+    if handler == nil {
+        handler = defaultErrorHandler
+    }
+    t.errors = handler
     return t
 }
 
@@ -100,6 +355,61 @@ func (t *configuration) FetchSize() int64 {
     return t.size
 }
 
+func (t *configuration) FetchSpinThreshold() time.Duration {
+    return t.spin
+}
+
+func (t *configuration) FetchQueueTrace() func(event QueueEvent) {
+    return t.trace
+}
+
+func (t *configuration) FetchOverflowLevels() int {
+    return t.levels
+}
+
+func (t *configuration) FetchQueueCapacity() int {
+    if t.capacity <= 0 {
+        return int(t.size)
+    }
+    return t.capacity
+}
+
 func (t *configuration) FetchExecutor() Executor {
-    return t.executor
+    return *t.executor.Load()
+}
+
+func (t *configuration) FetchBucketStorage() BucketStorage {
+    return t.storage
+}
+
+func (t *configuration) FetchLocation() *time.Location {
+    return t.location
+}
+
+func (t *configuration) FetchClock() Clock {
+    return t.clock
+}
+
+func (t *configuration) FetchDSTPolicy() DSTPolicy {
+    return t.dst
+}
+
+func (t *configuration) FetchName() string {
+    return t.name
+}
+
+func (t *configuration) FetchImmediateSync() bool {
+    return t.sync
+}
+
+func (t *configuration) FetchPanicHandler() func(err any) {
+    return t.panic
+}
+
+func (t *configuration) FetchErrorHandler() func(err error) {
+    return t.errors
+}
+
+func (t *configuration) fetchLevel() int {
+    return t.level
 }
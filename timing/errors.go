@@ -0,0 +1,12 @@
+package timing
+
+import "errors"
+
+// ErrInvalidCron 表示传入了无法解析的 cron 表达式或预定义描述符。
+//
+// 该错误由 CronSchedule、CronNext 及 Wheel.Cron 返回，返回的错误包装了原始表达式及底层的解析错误，应通过 errors.Is 进行判断。
+// 调用方可以据此区分由输入导致的错误与其他内部错误，例如在 API 层将前者映射为 400 而非 500。
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// ErrUnsupportedSpec 表示传入 Wheel.ScheduleFromSpec 的 ScheduleSpec 无法被重建，例如通过自定义调度获取的 SpecKindCustom。
+var ErrUnsupportedSpec = errors.New("unsupported schedule spec")
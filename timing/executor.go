@@ -5,6 +5,14 @@ import (
     "runtime/debug"
 )
 
+// Executor 是时间轮中任务的执行器，它决定了到期任务以何种方式被执行。
+//
+// 相同过期时间的任务将按照添加顺序依次交付给执行器。
+//
+// 关键行为说明：
+//  - 同步或单工作协程的执行器将按 FIFO 顺序执行相同过期时间的任务
+//  - 多工作协程的执行器不保证相同过期时间的任务的执行顺序
+//  - 同步执行器中耗时较长的任务会推迟同一时刻到期的后续任务
 type Executor interface {
     // Execute 执行任务
     Execute(task func())
@@ -21,3 +29,27 @@ func (f ExecutorFN) Execute(task func()) {
     }()
     f(task)
 }
+
+// attribute 为任务附加时间轮的名称，当任务发生 panic 时，将以携带名称的错误重新抛出，以便执行器记录的信息能够区分来源的时间轮
+//  - 当时间轮未设置名称时，将直接返回 task
+//  - 当 panic 的值为 error 时将被包装，可以通过 errors.Is 或 errors.As 取得原始错误
+func attribute(config OptionsFetcher, task func()) func() {
+    name := config.FetchName()
+    if name == "" {
+        return task
+    }
+    if level := config.fetchLevel(); level > 0 {
+        name = fmt.Sprintf("%s#%d", name, level)
+    }
+    return func() {
+        defer func() {
+            if err := recover(); err != nil {
+                if cause, ok := err.(error); ok {
+                    panic(fmt.Errorf("timing: wheel %s: %w", name, cause))
+                }
+                panic(fmt.Errorf("timing: wheel %s: %v", name, err))
+            }
+        }()
+        task()
+    }
+}
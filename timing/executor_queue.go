@@ -0,0 +1,131 @@
+package timing
+
+import (
+    "fmt"
+    "runtime/debug"
+    "sync"
+    "sync/atomic"
+)
+
+// OverflowPolicy 定义了执行器队列已满时对新任务的处理策略
+type OverflowPolicy int
+
+const (
+    OverflowPolicyBlock      OverflowPolicy = iota // OverflowPolicyBlock 表示队列已满时阻塞提交方，直到队列出现空位
+    OverflowPolicyDropNewest                       // OverflowPolicyDropNewest 表示队列已满时丢弃新提交的任务
+    OverflowPolicyDropOldest                       // OverflowPolicyDropOldest 表示队列已满时丢弃队列中最早的任务，以便容纳新提交的任务
+)
+
+var (
+    _ Executor = (*QueueExecutor)(nil)
+)
+
+// NewQueueExecutor 创建一个具有有界队列的执行器，提交的任务将在队列中排队，并由单个工作协程依次交由 executor 执行。
+//
+// 参数 capacity 指定了队列的容量，当其小于等于 0 时将被视为 1。参数 policy 指定了队列已满时的处理策略。
+// 相较于为每个到期任务创建协程，有界队列使得在过载时的行为变得可控。
+//
+// 关键行为说明：
+//  - 工作协程会在创建时启动，并在调用 Close 后处理完队列中剩余的任务时退出
+//  - 被丢弃的任务数量可以通过 Dropped 方法获取，同时也会体现在 Wheel.Stats 中
+//  - 当 executor 为同步执行器时，任务将按提交顺序依次执行
+//
+// 使用建议：
+//  - 实时性要求较高的场景可以选择 OverflowPolicyDropOldest，以优先执行最新的任务
+//  - 使用 OverflowPolicyBlock 时需注意，阻塞会向上传导至时间轮的调度流程
+//  - 不再使用时应当在停止时间轮后调用 Close，以避免工作协程泄漏
+func NewQueueExecutor(executor Executor, capacity int, policy OverflowPolicy) *QueueExecutor {
+    if capacity <= 0 {
+        capacity = 1
+    }
+    e := &QueueExecutor{
+        executor: executor,
+        policy:   policy,
+        queue:    make(chan func(), capacity),
+        done:     make(chan struct{}),
+    }
+    go e.work()
+    return e
+}
+
+// QueueExecutor 是具有有界队列及溢出策略的执行器，通过 NewQueueExecutor 创建
+type QueueExecutor struct {
+    executor Executor
+    policy   OverflowPolicy
+    queue    chan func()
+    dropped  atomic.Uint64
+    mu       sync.RWMutex
+    closed   bool
+    done     chan struct{}
+}
+
+// Execute 将任务提交至队列，当队列已满时根据溢出策略进行处理，当执行器已关闭时任务将被丢弃
+func (e *QueueExecutor) Execute(task func()) {
+    e.mu.RLock()
+    defer e.mu.RUnlock()
+    if e.closed {
+        e.dropped.Add(1)
+        return
+    }
+    switch e.policy {
+    case OverflowPolicyDropNewest:
+        select {
+        case e.queue <- task:
+        default:
+            e.dropped.Add(1)
+        }
+    case OverflowPolicyDropOldest:
+        for {
+            select {
+            case e.queue <- task:
+                return
+            default:
+            }
+            select {
+            case <-e.queue:
+                e.dropped.Add(1)
+            default:
+            }
+        }
+    default:
+        e.queue <- task
+    }
+}
+
+// Dropped 返回因队列已满或执行器已关闭而被丢弃的任务数量
+func (e *QueueExecutor) Dropped() uint64 {
+    return e.dropped.Load()
+}
+
+// Close 关闭执行器，并阻塞直到队列中剩余的任务均已交由 executor 执行且工作协程退出。
+//
+// 关键行为说明：
+//  - 关闭后提交的任务将被丢弃，并计入 Dropped
+//  - 重复调用是安全的，后续调用同样会等待工作协程退出
+//  - 不应在由该执行器执行的任务中调用，否则将导致死锁
+func (e *QueueExecutor) Close() {
+    e.mu.Lock()
+    if !e.closed {
+        e.closed = true
+        close(e.queue)
+    }
+    e.mu.Unlock()
+    <-e.done
+}
+
+func (e *QueueExecutor) work() {
+    defer close(e.done)
+    for task := range e.queue {
+        e.execute(task)
+    }
+}
+
+func (e *QueueExecutor) execute(task func()) {
+    defer func() {
+        if err := recover(); err != nil {
+            fmt.Println(err)
+            debug.PrintStack()
+        }
+    }()
+    e.executor.Execute(task)
+}
@@ -0,0 +1,169 @@
+package timing_test
+
+import (
+    "github.com/kercylan98/chrono/timing"
+    "reflect"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestQueueExecutor(t *testing.T) {
+    tests := []struct {
+        name     string
+        policy   timing.OverflowPolicy
+        executed []int
+        dropped  uint64
+    }{
+        {name: "Block", policy: timing.OverflowPolicyBlock, executed: []int{1, 2, 3, 4, 5}, dropped: 0},
+        {name: "DropNewest", policy: timing.OverflowPolicyDropNewest, executed: []int{1, 2, 3}, dropped: 2},
+        {name: "DropOldest", policy: timing.OverflowPolicyDropOldest, executed: []int{1, 4, 5}, dropped: 2},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var mu sync.Mutex
+            var executed []int
+            started := make(chan struct{})
+            release := make(chan struct{})
+            executor := timing.NewQueueExecutor(timing.ExecutorFN(func(task func()) {
+                task()
+            }), 2, tt.policy)
+
+            var wg sync.WaitGroup
+            submit := func(i int) {
+                wg.Add(1)
+                executor.Execute(func() {
+                    defer wg.Done()
+                    if i == 1 {
+                        close(started)
+                        <-release
+                    }
+                    mu.Lock()
+                    executed = append(executed, i)
+                    mu.Unlock()
+                })
+            }
+
+            // 第一个任务占用工作协程，使得后续任务在队列中排队直至饱和
+            submit(1)
+            <-started
+            submitted := make(chan struct{})
+            go func() {
+                for i := 2; i <= 5; i++ {
+                    submit(i)
+                }
+                close(submitted)
+            }()
+            if tt.policy != timing.OverflowPolicyBlock {
+                <-submitted
+            } else {
+                time.Sleep(50 * time.Millisecond)
+            }
+            close(release)
+            <-submitted
+
+            wg.Add(-int(executor.Dropped()))
+            wg.Wait()
+            if !reflect.DeepEqual(executed, tt.executed) {
+                t.Errorf("executed = %v, want %v", executed, tt.executed)
+            }
+            if dropped := executor.Dropped(); dropped != tt.dropped {
+                t.Errorf("Dropped() = %d, want %d", dropped, tt.dropped)
+            }
+        })
+    }
+}
+
+func TestQueueExecutor_Close(t *testing.T) {
+    release := make(chan struct{})
+    var executed atomic.Int32
+    executor := timing.NewQueueExecutor(timing.ExecutorFN(func(task func()) {
+        task()
+    }), 4, timing.OverflowPolicyBlock)
+
+    executor.Execute(func() {
+        <-release
+        executed.Add(1)
+    })
+    for i := 0; i < 3; i++ {
+        executor.Execute(func() {
+            executed.Add(1)
+        })
+    }
+
+    closed := make(chan struct{})
+    go func() {
+        executor.Close()
+        close(closed)
+    }()
+    select {
+    case <-closed:
+        t.Fatal("Close returned before the queue was drained")
+    case <-time.After(20 * time.Millisecond):
+    }
+    close(release)
+
+    select {
+    case <-closed:
+    case <-time.After(time.Second):
+        t.Fatal("Close did not return")
+    }
+    if n := executed.Load(); n != 4 {
+        t.Errorf("executed = %d, want 4", n)
+    }
+
+    executor.Execute(func() {
+        executed.Add(1)
+    })
+    executor.Close()
+    if n := executed.Load(); n != 4 {
+        t.Errorf("executed after Close = %d, want 4", n)
+    }
+    if dropped := executor.Dropped(); dropped != 1 {
+        t.Errorf("Dropped() = %d, want 1", dropped)
+    }
+}
+
+func TestWithExecutorQueue_OptionOrder(t *testing.T) {
+    tests := []struct {
+        name      string
+        configure func(c timing.Configuration, executor timing.Executor)
+    }{
+        {name: "QueueFirst", configure: func(c timing.Configuration, executor timing.Executor) {
+            c.WithExecutorQueue(1, timing.OverflowPolicyDropNewest).WithExecutor(executor)
+        }},
+        {name: "ExecutorFirst", configure: func(c timing.Configuration, executor timing.Executor) {
+            c.WithExecutor(executor).WithExecutorQueue(1, timing.OverflowPolicyDropNewest)
+        }},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            release := make(chan struct{})
+            defer close(release)
+            executor := timing.ExecutorFN(func(task func()) {
+                <-release
+                task()
+            })
+            w := timing.NewMockWheel(timing.ConfiguratorFN(func(c timing.Configuration) {
+                tt.configure(c, executor)
+            }))
+
+            // 首个任务占用工作协程，第二个任务填满队列，其余任务将被丢弃
+            for i := 0; i < 5; i++ {
+                w.After(time.Millisecond, timing.TaskFN(func() {}))
+            }
+            w.Advance(time.Millisecond)
+
+            deadline := time.Now().Add(time.Second)
+            for w.Stats().DroppedTasks == 0 && time.Now().Before(deadline) {
+                time.Sleep(time.Millisecond)
+            }
+            if dropped := w.Stats().DroppedTasks; dropped == 0 {
+                t.Error("DroppedTasks = 0, want tasks dropped by the queue")
+            }
+        })
+    }
+}
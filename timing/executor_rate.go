@@ -0,0 +1,58 @@
+package timing
+
+import (
+    "time"
+)
+
+var (
+    _ Executor = (*RateLimitExecutor)(nil)
+)
+
+// NewRateLimitExecutor 创建一个限制每秒执行任务数量的执行器，超出速率的任务将在有界队列中排队，并按照 perSecond 的速率依次交由 executor 执行。
+//
+// 当大量计时器在同一时刻到期时，该执行器可以将执行平滑地分散到后续的时间中，以保护下游系统免受同步到期带来的冲击。
+// 限速基于容量为 1 的令牌桶实现，即相邻两个任务的交付间隔不小于 time.Second / perSecond，不允许突发。
+// 参数 capacity 及 policy 与 NewQueueExecutor 一致，分别指定了排队任务的数量上限及队列已满时的处理策略。
+//
+// 关键行为说明：
+//  - 任务按照提交顺序交付，工作协程会在创建时启动，并在调用 Close 后处理完队列中剩余的任务时退出
+//  - 被丢弃的任务数量可以通过 Dropped 方法获取，同时也会体现在 Wheel.Stats 中
+//  - 当 perSecond 小于等于 0 时不进行限速，此时等同于 NewQueueExecutor
+//
+// 使用建议：
+//  - 长期超出速率的提交会使队列保持饱和，此时应选择 OverflowPolicyDropNewest 或 OverflowPolicyDropOldest，以避免阻塞时间轮的调度流程
+//  - 不再使用时应当在停止时间轮后调用 Close，以避免工作协程泄漏
+func NewRateLimitExecutor(executor Executor, perSecond, capacity int, policy OverflowPolicy) *RateLimitExecutor {
+    if perSecond > 0 {
+        executor = &rateLimiter{
+            executor: executor,
+            interval: time.Second / time.Duration(perSecond),
+        }
+    }
+    return &RateLimitExecutor{
+        QueueExecutor: NewQueueExecutor(executor, capacity, policy),
+    }
+}
+
+// RateLimitExecutor 是具有速率限制及有界队列的执行器，通过 NewRateLimitExecutor 创建
+type RateLimitExecutor struct {
+    *QueueExecutor
+}
+
+// rateLimiter 在交付任务前等待下一枚令牌，仅由 QueueExecutor 的工作协程调用，因此无需加锁
+type rateLimiter struct {
+    executor Executor
+    interval time.Duration // 相邻两个任务的最小交付间隔
+    next     time.Time     // 下一枚令牌的可用时间
+}
+
+func (e *rateLimiter) Execute(task func()) {
+    // 等待下一枚令牌，空闲期间不累积令牌，下一枚令牌以实际交付的时间为基准，避免等待超时后连续交付
+    now := time.Now()
+    if e.next.After(now) {
+        time.Sleep(e.next.Sub(now))
+        now = time.Now()
+    }
+    e.next = now.Add(e.interval)
+    e.executor.Execute(task)
+}
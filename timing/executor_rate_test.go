@@ -0,0 +1,104 @@
+package timing_test
+
+import (
+    "github.com/kercylan98/chrono/timing"
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestRateLimitExecutor(t *testing.T) {
+    executor := timing.NewRateLimitExecutor(timing.ExecutorFN(func(task func()) {
+        task()
+    }), 200, 20, timing.OverflowPolicyBlock)
+    defer executor.Close()
+
+    const tasks = 20
+    var mu sync.Mutex
+    var executed []time.Time
+    var wg sync.WaitGroup
+    wg.Add(tasks)
+    start := time.Now()
+    for i := 0; i < tasks; i++ {
+        executor.Execute(func() {
+            defer wg.Done()
+            mu.Lock()
+            executed = append(executed, time.Now())
+            mu.Unlock()
+        })
+    }
+    if submitted := time.Since(start); submitted > 20*time.Millisecond {
+        t.Errorf("submitting the burst took %v, want it not to block", submitted)
+    }
+
+    wg.Wait()
+    // 首个任务立即执行，其余 19 个任务按 5ms 的间隔依次执行
+    if elapsed := time.Since(start); elapsed < 90*time.Millisecond || elapsed > time.Second {
+        t.Errorf("burst of %d tasks at 200/s took %v, want about 95ms", tasks, elapsed)
+    }
+    for i := 1; i < len(executed); i++ {
+        if gap := executed[i].Sub(executed[i-1]); gap < 4*time.Millisecond {
+            t.Errorf("tasks %d and %d ran %v apart, want at least 5ms", i-1, i, gap)
+        }
+    }
+}
+
+func TestRateLimitExecutor_Overflow(t *testing.T) {
+    release := make(chan struct{})
+    executor := timing.NewRateLimitExecutor(timing.ExecutorFN(func(task func()) {
+        task()
+    }), 1000, 2, timing.OverflowPolicyDropNewest)
+
+    var mu sync.Mutex
+    var executed []int
+    started := make(chan struct{})
+    executor.Execute(func() {
+        close(started)
+        <-release
+    })
+    <-started
+    for i := 1; i <= 5; i++ {
+        executor.Execute(func() {
+            mu.Lock()
+            executed = append(executed, i)
+            mu.Unlock()
+        })
+    }
+    close(release)
+    executor.Close()
+
+    if len(executed) != 2 || executed[0] != 1 || executed[1] != 2 {
+        t.Errorf("executed = %v, want [1 2]", executed)
+    }
+    if dropped := executor.Dropped(); dropped != 3 {
+        t.Errorf("Dropped() = %d, want 3", dropped)
+    }
+
+    executor.Execute(func() {
+        t.Error("task submitted after Close was executed")
+    })
+    if dropped := executor.Dropped(); dropped != 4 {
+        t.Errorf("Dropped() after Close = %d, want 4", dropped)
+    }
+}
+
+func TestRateLimitExecutor_Unlimited(t *testing.T) {
+    // 不限速时等同于有界队列，任务将尽快执行
+    executor := timing.NewRateLimitExecutor(timing.ExecutorFN(func(task func()) {
+        task()
+    }), 0, 100, timing.OverflowPolicyBlock)
+    var executed int
+    start := time.Now()
+    for i := 0; i < 100; i++ {
+        executor.Execute(func() {
+            executed++
+        })
+    }
+    executor.Close()
+    if executed != 100 {
+        t.Errorf("executed = %d, want 100", executed)
+    }
+    if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+        t.Errorf("unlimited executor took %v, want no pacing", elapsed)
+    }
+}
@@ -0,0 +1,15 @@
+package timing
+
+import "time"
+
+// NewMonotonicClockWithWall 创建一个以 wall 作为墙上时间来源的单调时钟，用于在测试中模拟系统时钟的跳变
+func NewMonotonicClockWithWall(wall func() time.Time) Clock {
+    return &monotonicClock{start: time.Now(), wall: wall}
+}
+
+// ManualClockWaiters 返回 ManualClock 中尚未唤醒的等待者数量
+func ManualClockWaiters(c *ManualClock) int {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return len(c.waiters)
+}
@@ -2,25 +2,40 @@ package delayqueue
 
 import (
 	"container/heap"
-	"context"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-var defaultWakeupCancel = func() {}
-
 const (
 	delayQueueSleeping = iota
 	delayQueueWorking
 )
 
-func New[T QueueItem](size int, timeGetter func() int64, handler func(v T)) *DelayQueue[T] {
+// Event 定义了延迟队列的跟踪事件
+type Event int
+
+const (
+	EventSleep   Event = iota // 处理协程开始等待队首元素到期，delta 为计划等待的时长
+	EventWakeup               // 处理协程结束等待，delta 为相对于计划唤醒时间的偏差，负值表示被提前唤醒
+	EventProcess              // 到期的元素被处理，delta 为处理时相对于元素过期时间的延迟
+	EventRefresh              // 队列被要求重新检查队首元素，delta 始终为 0
+)
+
+// New 创建一个延迟队列。
+//   - timeGetter 返回当前时间，其单位需与元素的过期时间一致
+//   - waiter 返回一个在经过 delta（与 timeGetter 的单位一致）后可读的通道，用于等待队首元素到期，
+//     以及一个可选的取消函数，当等待因队首元素变化而被放弃时将被调用，以便释放等待所占用的资源
+//   - handler 在元素到期时被调用
+//   - onPanic 在 handler 发生 panic 时被调用，随后队列将继续处理后续元素，为 nil 时 panic 将被静默丢弃
+func New[T QueueItem](size int, timeGetter func() int64, waiter func(delta int64) (<-chan time.Time, func()), handler func(v T), onPanic func(err any)) *DelayQueue[T] {
 	return &DelayQueue[T]{
 		priorityQueue: newPriorityQueue[T](size),
 		timeGetter:    timeGetter,
+		waiter:        waiter,
 		handler:       handler,
-		wakeupCancel:  defaultWakeupCancel,
+		onPanic:       onPanic,
+		wakeupC:       make(chan struct{}, 1),
 	}
 }
 
@@ -34,9 +49,18 @@ type DelayQueue[T QueueItem] struct {
 	mu            sync.Mutex
 	priorityQueue priorityQueue[T]
 	timeGetter    func() int64
+	waiter        func(delta int64) (<-chan time.Time, func())
 	handler       func(v T)
-	wakeupCtx     context.Context
-	wakeupCancel  context.CancelFunc
+	onPanic       func(err any)
+	tracer        func(event Event, now, delta int64)
+	wakeupC       chan struct{}
+}
+
+// Trace 设置队列的跟踪函数，now 与 delta 的单位与 timeGetter 一致，为 nil 时不进行跟踪。
+//   - 跟踪函数可能在处理协程及调用 Refresh 的协程中被并发调用
+//   - 必须在首次调用 Add 之前设置
+func (q *DelayQueue[T]) Trace(tracer func(event Event, now, delta int64)) {
+	q.tracer = tracer
 }
 
 // Add 将元素插入到当前队列中。
@@ -51,13 +75,31 @@ func (q *DelayQueue[T]) Add(elem T, expiration int64) {
 		go q.wakeup()
 	} else {
 		q.n.Add(1)
-		q.wakeupCancel()
+		q.notify()
 	}
 }
 
+// Len 返回当前队列中等待到期的元素数量。
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.priorityQueue.Len()
+}
+
 // Refresh 刷新元素的过期时间。
 func (q *DelayQueue[T]) Refresh() {
-	q.wakeupCancel()
+	if q.tracer != nil {
+		q.tracer(EventRefresh, q.timeGetter(), 0)
+	}
+	q.notify()
+}
+
+// notify 唤醒正在等待队首元素到期的处理协程，使其重新检查队首元素
+func (q *DelayQueue[T]) notify() {
+	select {
+	case q.wakeupC <- struct{}{}:
+	default:
+	}
 }
 
 func (q *DelayQueue[T]) wakeup() {
@@ -82,21 +124,46 @@ func (q *DelayQueue[T]) process() {
 		item, delta := q.priorityQueue.PeekAndShift(now)
 		q.mu.Unlock()
 
-		if item == nil || item.Value.Size() == 0 {
+		if item == nil {
 			break // 没有任何元素待处理
 		}
 
 		if delta > 0 {
-
-			after := time.Duration(delta)
-			q.wakeupCtx, q.wakeupCancel = context.WithTimeout(context.Background(), after)
+			if q.tracer != nil {
+				q.tracer(EventSleep, now, delta)
+			}
+			wait, cancel := q.waiter(delta)
 			select {
-			case <-q.wakeupCtx.Done():
-				continue
+			case <-wait:
+			case <-q.wakeupC:
+				if cancel != nil {
+					cancel()
+				}
+			}
+			if q.tracer != nil {
+				woke := q.timeGetter()
+				q.tracer(EventWakeup, woke, woke-now-delta)
 			}
+			continue
 		}
 
-		q.handler(item.Value)
-
+		if item.Value.Size() == 0 {
+			// 同一元素可能因过期时间变化而被多次加入队列，已被处理或清空的元素直接丢弃，不能中断对后续元素的处理
+			continue
+		}
+		if q.tracer != nil {
+			q.tracer(EventProcess, now, now-item.Priority)
+		}
+		q.handle(item.Value)
 	}
 }
+
+// handle 调用 handler 处理到期的元素，并捕获其中发生的 panic，以避免处理协程退出导致队列永久停滞
+func (q *DelayQueue[T]) handle(v T) {
+	defer func() {
+		if err := recover(); err != nil && q.onPanic != nil {
+			q.onPanic(err)
+		}
+	}()
+	q.handler(v)
+}
@@ -0,0 +1,142 @@
+package delayqueue
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testItem int
+
+func (testItem) Size() int {
+	return 1
+}
+
+func TestDelayQueue_HandlerPanic(t *testing.T) {
+	var panics atomic.Int32
+	handled := make(chan testItem, 3)
+	q := New[testItem](4, func() int64 {
+		return time.Now().UnixMilli()
+	}, func(delta int64) (<-chan time.Time, func()) {
+		return time.After(time.Duration(delta) * time.Millisecond), nil
+	}, func(v testItem) {
+		if v == 1 {
+			panic("bad bucket")
+		}
+		handled <- v
+	}, func(err any) {
+		panics.Add(1)
+	})
+
+	now := time.Now().UnixMilli()
+	for i := 1; i <= 3; i++ {
+		q.Add(testItem(i), now+int64(i)*10)
+	}
+
+	for _, expected := range []testItem{2, 3} {
+		select {
+		case v := <-handled:
+			if v != expected {
+				t.Errorf("handled %d, want %d", v, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("item %d was not handled after the handler panicked", expected)
+		}
+	}
+	if n := panics.Load(); n != 1 {
+		t.Errorf("onPanic called %d times, want 1", n)
+	}
+
+	q.Add(testItem(4), time.Now().UnixMilli())
+	select {
+	case v := <-handled:
+		if v != 4 {
+			t.Errorf("handled %d, want 4", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("item added after the panic was not handled")
+	}
+}
+
+func TestDelayQueue_Trace(t *testing.T) {
+	type traced struct {
+		event      Event
+		now, delta int64
+	}
+	var (
+		clock  atomic.Int64
+		mu     sync.Mutex
+		events []traced
+	)
+	handled := make(chan testItem, 1)
+	q := New[testItem](4, clock.Load, func(delta int64) (<-chan time.Time, func()) {
+		// 模拟晚于计划 2 个单位唤醒
+		clock.Add(delta + 2)
+		c := make(chan time.Time, 1)
+		c <- time.Time{}
+		return c, nil
+	}, func(v testItem) {
+		handled <- v
+	}, nil)
+	q.Trace(func(event Event, now, delta int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, traced{event, now, delta})
+	})
+
+	q.Add(testItem(1), 10)
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatalf("item was not handled")
+	}
+	q.Refresh()
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []traced{
+		{EventSleep, 0, 10},
+		{EventWakeup, 12, 2},
+		{EventProcess, 12, 2},
+		{EventRefresh, 12, 0},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("traced events = %v, want %v", events, expected)
+	}
+}
+
+type sizedItem struct {
+	id   int
+	size int
+}
+
+func (i *sizedItem) Size() int {
+	return i.size
+}
+
+func TestDelayQueue_SkipEmpty(t *testing.T) {
+	handled := make(chan int, 2)
+	q := New[*sizedItem](4, func() int64 {
+		return time.Now().UnixMilli()
+	}, func(delta int64) (<-chan time.Time, func()) {
+		return time.After(time.Duration(delta) * time.Millisecond), nil
+	}, func(v *sizedItem) {
+		handled <- v.id
+	}, nil)
+
+	// 已被清空的元素先于有效元素到期，不应中断对后续元素的处理
+	now := time.Now().UnixMilli()
+	q.Add(&sizedItem{id: 1, size: 0}, now+10)
+	q.Add(&sizedItem{id: 2, size: 1}, now+20)
+
+	select {
+	case id := <-handled:
+		if id != 2 {
+			t.Errorf("handled item %d, want 2", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("item queued behind an emptied item was not handled")
+	}
+}
@@ -7,6 +7,7 @@ func newPriorityQueue[T any](capacity int) priorityQueue[T] {
 }
 
 // priorityQueue 是一个最小堆实现的优先队列
+//   - 容量在增长后将保持在历史最高水位，不会随元素出队而收缩
 type priorityQueue[T any] []*priorityQueueItem[T]
 
 func (pq *priorityQueue[T]) Len() int {
@@ -24,27 +25,15 @@ func (pq *priorityQueue[T]) Swap(i, j int) {
 }
 
 func (pq *priorityQueue[T]) Push(x interface{}) {
-	n := len(*pq)
-	c := cap(*pq)
-	if n+1 > c {
-		npq := make(priorityQueue[T], n, c*2)
-		copy(npq, *pq)
-		*pq = npq
-	}
-	*pq = (*pq)[0 : n+1]
-	item := x.(*priorityQueueItem[T])
-	(*pq)[n] = item
+	// 容量不足时由 append 按倍数扩容，扩容后的容量将作为高水位保留
+	*pq = append(*pq, x.(*priorityQueueItem[T]))
 }
 
 func (pq *priorityQueue[T]) Pop() interface{} {
+	// 出队时不收缩容量，避免在稳态的插入与到期交替过程中反复分配与拷贝
 	n := len(*pq)
-	c := cap(*pq)
-	if n < (c/2) && c > 25 {
-		npq := make(priorityQueue[T], n, c/2)
-		copy(npq, *pq)
-		*pq = npq
-	}
 	item := (*pq)[n-1]
+	(*pq)[n-1] = nil
 	*pq = (*pq)[0 : n-1]
 	return item
 }
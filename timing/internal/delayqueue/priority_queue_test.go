@@ -0,0 +1,28 @@
+package delayqueue
+
+import (
+	"container/heap"
+	"testing"
+)
+
+type benchmarkItem struct{}
+
+func (benchmarkItem) Size() int {
+	return 1
+}
+
+// BenchmarkPriorityQueue 模拟时间轮在稳态下交替插入与到期移除桶的过程
+func BenchmarkPriorityQueue(b *testing.B) {
+	const steady = 1024
+	b.ReportAllocs()
+	pq := newPriorityQueue[benchmarkItem](20)
+	for i := 0; i < b.N; i++ {
+		// 在 1 与 steady 之间往复，以覆盖扩容与收缩的边界
+		for j := 0; j < steady; j++ {
+			heap.Push(&pq, newPriorityQueueItem(benchmarkItem{}, int64(i*steady+j)))
+		}
+		for j := 0; j < steady; j++ {
+			pq.PeekAndShift(int64(i*steady + j))
+		}
+	}
+}
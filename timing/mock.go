@@ -0,0 +1,204 @@
+package timing
+
+import (
+    "github.com/kercylan98/chrono"
+    "github.com/kercylan98/chrono/timing/internal/delayqueue"
+    "sync"
+    "time"
+)
+
+var (
+    _ Wheel = (*MockWheel)(nil)
+)
+
+// NewMockWheel 创建一个用于测试的时间轮，它以当前时间作为初始时间，且不会启动任何后台协程。
+//
+// 依赖 Wheel 的代码可以通过注入 MockWheel 来断言任务的调度情况，例如 "5 秒后安排了一次重试"，而无需依赖真实时间的等待。
+// 可选的 configurator 可用于设置时区、执行器等配置，当通过 WithClock 设置了 ManualClock 时将以其作为时间源，
+// 从而可以指定初始时间，其他类型的时间源将被替换为以当前时间创建的 ManualClock。
+func NewMockWheel(configurator ...Configurator) *MockWheel {
+    w := &MockWheel{
+        config: NewConfig(),
+    }
+    for _, c := range configurator {
+        c.Configure(w.config)
+    }
+    w.config.buildExecutor()
+    if clock, ok := w.config.FetchClock().(*ManualClock); ok {
+        w.clock = clock
+    } else {
+        w.clock = NewManualClock(time.Now())
+        w.config.WithClock(w.clock)
+    }
+    w.wheel.wheelInternal = w
+    return w
+}
+
+// MockWheel 是一个手动推进的 Wheel 实现，通过 NewMockWheel 创建。
+//
+// 所有计时器均记录在内存中，仅在调用 Advance 时按照过期时间顺序在调用方的协程中同步执行。
+// After、Loop、Cron 等方法对当前时间的读取均来自内部的 ManualClock，可以通过 Now 获取。
+//
+// 关键行为说明：
+//  - 除非设置了 WithImmediateSync，延迟为零或负值的任务同样不会立即执行，而是在下一次调用 Advance（包括 Advance(0)）时执行
+//  - Advance 会依次将时钟推进至每个到期计时器的过期时间后再执行，因此循环任务在一次较大的推进中将按其间隔多次执行
+//  - 任务执行过程中发生的 panic 将被捕获并记录，与默认执行器的行为一致
+//  - 由于不存在延迟队列，Stats 返回的 PendingBuckets 始终为 0
+//  - 由于不存在计时桶，BucketSizes 及 OverflowBucketSizes 始终返回 nil，Timer.Scheduled 始终返回 false
+type MockWheel struct {
+    wheel
+    clock  *ManualClock
+    config Configuration
+    mu     sync.Mutex
+    timers []Timer // 按添加顺序记录的计时器
+    paused bool    // 是否已暂停
+}
+
+// Now 返回 MockWheel 当前的时间
+func (w *MockWheel) Now() time.Time {
+    return w.clock.Now()
+}
+
+// Advance 将时间向后推进 d，并在调用方的协程中同步执行期间到期的所有任务。
+//
+// 到期任务按照过期时间升序执行，相同过期时间的任务按照添加顺序执行，执行过程中新添加且在推进范围内到期的任务同样会被执行。
+// 时间轮暂停期间仅推进时间，到期的任务将在调用 Resume 时执行。
+func (w *MockWheel) Advance(d time.Duration) {
+    target := w.clock.Now().Add(d)
+    if w.isPaused() {
+        w.clock.Set(target)
+        return
+    }
+    deadline := chrono.ToMillisecond(target)
+    for {
+        timer := w.pop(deadline)
+        if timer == nil {
+            break
+        }
+        if at := chrono.ToTime(timer.getExpiration()); at.After(w.clock.Now()) {
+            w.clock.Set(at)
+        }
+        w.config.FetchExecutor().Execute(attribute(w.config, timer.getTask()))
+    }
+    if target.After(w.clock.Now()) {
+        w.clock.Set(target)
+    }
+}
+
+// Pending 返回尚未执行且未被停止的计时器数量
+func (w *MockWheel) Pending() int {
+    return w.timerCount()
+}
+
+// pop 移除并返回过期时间不晚于 deadline 且最早到期的计时器，不存在时返回 nil
+func (w *MockWheel) pop(deadline int64) Timer {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.prune()
+    index := -1
+    for i, timer := range w.timers {
+        if expiration := timer.getExpiration(); expiration <= deadline && (index < 0 || expiration < w.timers[index].getExpiration()) {
+            index = i
+        }
+    }
+    if index < 0 {
+        return nil
+    }
+    timer := w.timers[index]
+    w.timers = append(w.timers[:index], w.timers[index+1:]...)
+    return timer
+}
+
+// prune 移除已经停止的计时器
+func (w *MockWheel) prune() {
+    timers := w.timers[:0]
+    for _, timer := range w.timers {
+        if !timer.Stopped() {
+            timers = append(timers, timer)
+        }
+    }
+    w.timers = timers
+}
+
+// isPaused 返回时间轮是否已暂停
+func (w *MockWheel) isPaused() bool {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    return w.paused
+}
+
+func (w *MockWheel) init(int64, *delayqueue.DelayQueue[bucket]) {}
+
+func (w *MockWheel) getConfig() OptionsFetcher {
+    return w.config
+}
+
+func (w *MockWheel) add(timer Timer) bool {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.timers = append(w.timers, timer)
+    return true
+}
+
+func (w *MockWheel) advanceClock(int64) {}
+
+func (w *MockWheel) contract(timer Timer) {
+    if timer.Stopped() {
+        return
+    }
+    w.add(timer)
+}
+
+func (w *MockWheel) transfer(timer Timer) {
+    w.contract(timer)
+}
+
+func (w *MockWheel) refreshDelayQueue() {}
+
+func (w *MockWheel) timerCount() int {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.prune()
+    return len(w.timers)
+}
+
+func (w *MockWheel) pendingBuckets() int {
+    return 0
+}
+
+func (w *MockWheel) setExecutor(executor Executor) {
+    w.config.setExecutor(executor)
+}
+
+func (w *MockWheel) bucketSizes(levels [][]int) [][]int {
+    return levels
+}
+
+func (w *MockWheel) upcoming(deadline int64, dst []Timer) []Timer {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.prune()
+    for _, timer := range w.timers {
+        if timer.getExpiration() <= deadline {
+            dst = append(dst, timer)
+        }
+    }
+    return dst
+}
+
+func (w *MockWheel) pause() {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.paused = true
+}
+
+func (w *MockWheel) resume() {
+    w.mu.Lock()
+    paused := w.paused
+    w.paused = false
+    w.mu.Unlock()
+
+    if paused {
+        w.Advance(0)
+    }
+}
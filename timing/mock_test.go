@@ -0,0 +1,48 @@
+package timing_test
+
+import (
+    "github.com/kercylan98/chrono/timing"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestMockWheel(t *testing.T) {
+    tw := timing.NewMockWheel()
+    var executed []string
+    record := func(name string) timing.Task {
+        return timing.TaskFN(func() {
+            executed = append(executed, name)
+        })
+    }
+
+    tw.After(5*time.Second, record("retry"))
+    tw.After(0, record("now"))
+    stopped := tw.After(time.Second, record("stopped"))
+    tw.Loop(2*time.Second, timing.NewLoopTask(2*time.Second, 3, record("loop")))
+    stopped.Stop()
+
+    if pending := tw.Pending(); pending != 3 {
+        t.Errorf("Pending() = %d, want 3", pending)
+    }
+    if len(executed) != 0 {
+        t.Fatalf("executed %v before Advance, want nothing", executed)
+    }
+
+    tw.Advance(0)
+    if want := []string{"now"}; !reflect.DeepEqual(executed, want) {
+        t.Errorf("executed %v after Advance(0), want %v", executed, want)
+    }
+
+    start := tw.Now()
+    tw.Advance(10 * time.Second)
+    if want := []string{"now", "loop", "loop", "retry", "loop"}; !reflect.DeepEqual(executed, want) {
+        t.Errorf("executed %v after Advance(10s), want %v", executed, want)
+    }
+    if elapsed := tw.Now().Sub(start); elapsed != 10*time.Second {
+        t.Errorf("Now() advanced %v, want 10s", elapsed)
+    }
+    if pending := tw.Pending(); pending != 0 {
+        t.Errorf("Pending() = %d, want 0", pending)
+    }
+}
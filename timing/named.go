@@ -1,7 +1,9 @@
 package timing
 
 import (
+    "context"
     "sync"
+    "sync/atomic"
     "time"
 )
 
@@ -10,12 +12,12 @@ import (
 // 通过 After 方法可以创建一个在指定时长后执行的任务。
 // Loop 方法允许创建循环任务，该任务将在首次延迟后开始，并根据 LoopTask.Next 方法返回的时间间隔重复执行。
 // Cron 方法则利用 cron 表达式定义更复杂的调度模式，当表达式无效时会返回错误。
-// Stop 和 Clear 分别用于停止特定名称的任务或清除所有任务。
+// Stop 和 Clear 分别用于停止特定名称的任务或清除所有任务，StopKind 用于停止特定调度类型的所有任务。
 // Timer 方法提供了访问底层时间轮 API 的方式，以实现更精细的任务控制。
 //
 // 关键行为说明：
-//  - 同名任务会被新任务覆盖，确保任务唯一性
-//  - 停止任务时，正在执行的任务将完成当前操作后再退出
+//  - 同名任务会被新任务覆盖，确保任务唯一性，替换操作是原子的，并发地注册同名任务时最终仅有一个任务保留
+//  - 停止任务时，正在执行的任务将完成当前操作后再退出，可以通过 StopWait 等待其完成
 //  - 使用 Cron 时需保证表达式正确，否则任务不会被创建
 type Named interface {
     wheelInternal
@@ -58,6 +60,19 @@ type Named interface {
     //  - 异常处理机制会捕获并记录执行过程中的 panic，但不会中断任务调度流程
     Cron(name string, cron string, task Task) error
 
+    // AfterIfAbsent 与 After 一致，但当已存在有效的同名任务时不会进行替换，而是返回 false，适用于 "任务仅注册一次" 的场景。
+    //
+    // 已停止的任务及已执行的一次性任务将被视为无效，此时将注册新任务并返回 true。
+    AfterIfAbsent(name string, duration time.Duration, task Task) bool
+
+    // LoopIfAbsent 与 Loop 一致，但当已存在有效的同名任务时不会进行替换，而是返回 false，有效性的判断与 AfterIfAbsent 一致。
+    LoopIfAbsent(name string, duration time.Duration, task LoopTask) bool
+
+    // CronIfAbsent 与 Cron 一致，但当已存在有效的同名任务时不会进行替换，而是返回 false，有效性的判断与 AfterIfAbsent 一致。
+    //
+    // 当表达式无效时返回错误，此时已存在的同名任务不受影响。
+    CronIfAbsent(name string, cron string, task Task) (bool, error)
+
     // Stop 停止指定名称的任务。
     //
     // name 参数用于标识要停止的任务。如果任务正在执行，它将完成当前操作后再退出。
@@ -66,6 +81,16 @@ type Named interface {
     //  - 正在执行的任务会完成当前操作再退出
     Stop(name string)
 
+    // StopWait 与 Stop 相同，停止并移除指定名称的任务，但将阻塞至该任务正在进行的执行全部完成，适用于服务关闭时的清理。
+    //
+    // 当 ctx 在执行完成前被取消时，将返回 ctx.Err()，此时任务已被停止，正在进行的执行仍将继续直至完成。
+    //
+    // 关键行为说明：
+    //  - 当指定名称的任务不存在或未在执行时，将立即返回 nil
+    //  - 返回后该任务不会再开始任何新的执行，即便其在停止前已经交由执行器排队
+    //  - 在任务自身的执行过程中以同一名称调用该方法将导致永久阻塞，直至 ctx 被取消
+    StopWait(name string, ctx context.Context) error
+
     // Clear 清除所有已注册的任务。
     //
     // 该方法会立即停止并清除当前命名空间下的所有任务，包括正在执行的任务也会被取消。
@@ -75,62 +100,205 @@ type Named interface {
     //  - 正在执行的任务会完成当前操作再退出
     Clear()
 
+    // StopKind 停止并移除当前命名空间下所有指定调度类型的任务，其他类型的任务不受影响。
+    //
+    // 例如 StopKind(KindCron) 可以暂停所有周期性的报表任务，同时保留尚未执行的一次性任务。
+    //
+    // 关键行为说明：
+    //  - 正在执行的任务会完成当前操作再退出
+    StopKind(kind ScheduleKind)
+
     // Timer 获取使用 Timer 维护任务的时间轮 API
     Timer() Wheel
 }
 
+// ScheduleKind 表示命名任务的调度类型，由注册任务时所使用的方法决定
+type ScheduleKind int
+
+const (
+    KindAfter ScheduleKind = iota // 通过 Named.After 注册的一次性任务
+    KindLoop                      // 通过 Named.Loop 注册的循环任务
+    KindCron                      // 通过 Named.Cron 注册的 cron 任务
+)
+
+// namedTimer 是命名任务的计时器及其调度类型
+type namedTimer struct {
+    Timer
+    kind     ScheduleKind
+    fired    *atomic.Bool // 一次性任务是否已经执行，仅 KindAfter 有效
+    inflight *inflight    // 任务正在进行的执行
+}
+
+// active 返回命名任务是否仍然有效，已停止的任务及已执行的一次性任务将被视为无效
+func (n namedTimer) active() bool {
+    return !n.Stopped() && (n.fired == nil || !n.fired.Load())
+}
+
 func newNamed(t Wheel) Named {
     return &named{
         Wheel:  t,
-        timers: make(map[string]Timer),
+        timers: make(map[string]namedTimer),
+        claims: make(map[string]uint64),
     }
 }
 
 type named struct {
     Wheel
-    timers map[string]Timer
+    timers map[string]namedTimer
+    claims map[string]uint64 // 正在创建计时器的 IfAbsent 注册所占用的名称及其序号
+    seq    uint64            // 最近一次占用名称的序号
     lock   sync.RWMutex
 }
 
 func (t *named) After(name string, duration time.Duration, task Task) {
-    t.lock.Lock()
-    if old, ok := t.timers[name]; ok {
-        old.Stop()
-    }
-    t.timers[name] = t.Wheel.After(duration, task)
-    t.lock.Unlock()
+    t.register(name, true, t.after(duration, task))
+}
+
+func (t *named) AfterIfAbsent(name string, duration time.Duration, task Task) bool {
+    added, _ := t.register(name, false, t.after(duration, task))
+    return added
 }
 
 func (t *named) Loop(name string, duration time.Duration, task LoopTask) {
-    t.lock.Lock()
-    if old, ok := t.timers[name]; ok {
-        old.Stop()
-    }
-    t.timers[name] = t.Wheel.Loop(duration, task)
-    t.lock.Unlock()
+    t.register(name, true, t.loop(duration, task))
+}
+
+func (t *named) LoopIfAbsent(name string, duration time.Duration, task LoopTask) bool {
+    added, _ := t.register(name, false, t.loop(duration, task))
+    return added
 }
 
 func (t *named) Cron(name string, cron string, task Task) error {
-    if timer, err := t.Wheel.Cron(cron, task); err != nil {
-        return err
-    } else {
+    _, err := t.register(name, true, t.cron(cron, task))
+    return err
+}
+
+func (t *named) CronIfAbsent(name string, cron string, task Task) (bool, error) {
+    return t.register(name, false, t.cron(cron, task))
+}
+
+func (t *named) after(duration time.Duration, task Task) func() (namedTimer, error) {
+    return func() (namedTimer, error) {
+        fired, tracked := new(atomic.Bool), new(inflight)
+        timer := t.Wheel.After(duration, afterTask{trackedTask{task, tracked}, fired})
+        return namedTimer{timer, KindAfter, fired, tracked}, nil
+    }
+}
+
+func (t *named) loop(duration time.Duration, task LoopTask) func() (namedTimer, error) {
+    return func() (namedTimer, error) {
+        tracked := new(inflight)
+        timer := t.Wheel.Loop(duration, trackedLoopTask{trackedTask{task, tracked}, task})
+        if loop, ok := task.(*loopTask); ok {
+            timer.setSpec(loop.spec)
+        }
+        return namedTimer{Timer: timer, kind: KindLoop, inflight: tracked}, nil
+    }
+}
+
+func (t *named) cron(cron string, task Task) func() (namedTimer, error) {
+    return func() (namedTimer, error) {
+        tracked := new(inflight)
+        timer, err := t.Wheel.Cron(cron, trackedTask{task, tracked})
+        return namedTimer{Timer: timer, kind: KindCron, inflight: tracked}, err
+    }
+}
+
+// register 以 create 创建的计时器注册名为 name 的任务，替换操作对并发的注册及停止保持原子性
+//  - create 在锁外执行，以避免 WithImmediateSync 下同步执行的任务调用 Named 的方法时发生死锁
+//  - 当 replace 为 false 时，将在锁内检查并占用名称后再创建计时器，已存在有效的同名任务或名称已被占用时不会创建计时器并返回 false，
+//    从而确保即便任务在 create 期间便已执行，同名任务也仅会被注册一次
+//  - 占用期间名称被 replace 为 true 的注册、Stop 或 Clear 接管时，新创建的计时器将被停止，视为注册后立即被替换或停止
+//  - 当 create 返回错误时，已存在的同名任务不受影响
+func (t *named) register(name string, replace bool, create func() (namedTimer, error)) (bool, error) {
+    if replace {
+        timer, err := create()
+        if err != nil {
+            return false, err
+        }
         t.lock.Lock()
-        if old, ok := t.timers[name]; ok {
+        defer t.lock.Unlock()
+        if old, exists := t.timers[name]; exists {
             old.Stop()
         }
+        delete(t.claims, name)
         t.timers[name] = timer
-        t.lock.Unlock()
+        return true, nil
+    }
+
+    claim, ok := t.claim(name)
+    if !ok {
+        return false, nil
     }
-    return nil
+    timer, err := create()
+
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    if t.claims[name] != claim {
+        // 名称已被其他注册或停止操作接管
+        if err == nil {
+            timer.Stop()
+        }
+        return err == nil, err
+    }
+    delete(t.claims, name)
+    if err != nil {
+        return false, err
+    }
+    if old, exists := t.timers[name]; exists {
+        old.Stop()
+    }
+    t.timers[name] = timer
+    return true, nil
+}
+
+// claim 在不存在有效的同名任务且名称未被占用时占用名称 name，并返回占用的序号
+func (t *named) claim(name string) (uint64, bool) {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    if timer, exists := t.timers[name]; exists && timer.active() {
+        return 0, false
+    }
+    if _, claimed := t.claims[name]; claimed {
+        return 0, false
+    }
+    t.seq++
+    t.claims[name] = t.seq
+    return t.seq, true
 }
 
 func (t *named) Stop(name string) {
+    t.remove(name)
+}
+
+func (t *named) StopWait(name string, ctx context.Context) error {
+    timer, ok := t.remove(name)
+    if !ok {
+        return nil
+    }
+    idle := timer.inflight.stop()
+    if idle == nil {
+        return nil
+    }
+    select {
+    case <-idle:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// remove 停止并移除名为 name 的任务，并返回被移除的任务
+func (t *named) remove(name string) (namedTimer, bool) {
     t.lock.Lock()
-    if timer, ok := t.timers[name]; ok {
+    defer t.lock.Unlock()
+    delete(t.claims, name)
+    timer, ok := t.timers[name]
+    if ok {
         timer.Stop()
+        delete(t.timers, name)
     }
-    delete(t.timers, name)
-    t.lock.Unlock()
+    return timer, ok
 }
 
 func (t *named) Clear() {
@@ -138,10 +306,107 @@ func (t *named) Clear() {
     for _, timer := range t.timers {
         timer.Stop()
     }
-    t.timers = make(map[string]Timer)
+    t.timers = make(map[string]namedTimer)
+    t.claims = make(map[string]uint64)
+    t.lock.Unlock()
+}
+
+func (t *named) StopKind(kind ScheduleKind) {
+    t.lock.Lock()
+    for name, timer := range t.timers {
+        if timer.kind == kind {
+            timer.Stop()
+            delete(t.timers, name)
+        }
+    }
     t.lock.Unlock()
 }
 
 func (t *named) Timer() Wheel {
     return t.Wheel
 }
+
+// inflight 记录命名任务正在进行的执行，以便 StopWait 等待执行完成
+type inflight struct {
+    mu      sync.Mutex
+    running int           // 正在进行的执行数量
+    idle    chan struct{} // 在 running 归零时关闭，running 为 0 时为 nil
+    stopped bool          // 是否已拒绝新的执行
+}
+
+// run 在计数范围内执行 task，当 f 已经停止时将直接放弃执行
+func (f *inflight) run(task Task, scheduled time.Time, handler func(err error)) {
+    f.mu.Lock()
+    if f.stopped {
+        f.mu.Unlock()
+        return
+    }
+    if f.running == 0 {
+        f.idle = make(chan struct{})
+    }
+    f.running++
+    f.mu.Unlock()
+
+    defer func() {
+        f.mu.Lock()
+        if f.running--; f.running == 0 {
+            close(f.idle)
+            f.idle = nil
+        }
+        f.mu.Unlock()
+    }()
+    execute(task, scheduled, handler)
+}
+
+// stop 拒绝后续的执行，并返回在正在进行的执行全部完成时关闭的通道，不存在正在进行的执行时返回 nil
+func (f *inflight) stop() <-chan struct{} {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.stopped = true
+    return f.idle
+}
+
+// trackedTask 通过 inflight 记录 Task 正在进行的执行，并将时间源的绑定及错误处理函数转发至被包装的任务
+type trackedTask struct {
+    Task
+    inflight *inflight
+}
+
+func (f trackedTask) ExecuteAt(scheduled time.Time) {
+    f.executeWith(scheduled, nil)
+}
+
+func (f trackedTask) executeWith(scheduled time.Time, handler func(err error)) {
+    f.inflight.run(f.Task, scheduled, handler)
+}
+
+func (f trackedTask) bindClock(clock Clock) {
+    if aware, ok := f.Task.(clockAware); ok {
+        aware.bindClock(clock)
+    }
+}
+
+// trackedLoopTask 是 LoopTask 的 trackedTask，下一次执行时间由被包装的任务决定
+type trackedLoopTask struct {
+    trackedTask
+    loop LoopTask
+}
+
+func (f trackedLoopTask) Next(previous time.Time) time.Time {
+    return f.loop.Next(previous)
+}
+
+// afterTask 是命名的一次性任务，在执行前标记其已经执行
+type afterTask struct {
+    trackedTask
+    fired *atomic.Bool
+}
+
+func (f afterTask) ExecuteAt(scheduled time.Time) {
+    f.executeWith(scheduled, nil)
+}
+
+func (f afterTask) executeWith(scheduled time.Time, handler func(err error)) {
+    f.fired.Store(true)
+    f.trackedTask.executeWith(scheduled, handler)
+}
@@ -0,0 +1,261 @@
+package timing_test
+
+import (
+    "context"
+    "errors"
+    "github.com/kercylan98/chrono/timing"
+    "reflect"
+    "sort"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestNamed_StopKind(t *testing.T) {
+    tw := timing.NewMockWheel()
+    named := tw.Named()
+    counts := make(map[string]int)
+    record := func(name string) timing.TaskFN {
+        return func() {
+            counts[name]++
+        }
+    }
+
+    named.After("after", 5*time.Second, record("after"))
+    named.Loop("loop", time.Second, timing.NewForeverLoopTask(time.Second, record("loop")))
+    if err := named.Cron("cron", "@every 1s", record("cron")); err != nil {
+        t.Fatalf("Cron() error = %v", err)
+    }
+
+    tw.Advance(2 * time.Second)
+    named.StopKind(timing.KindCron)
+    tw.Advance(4 * time.Second)
+
+    if counts["cron"] != 2 {
+        t.Errorf("cron executed %d times, want 2 before StopKind", counts["cron"])
+    }
+    if counts["loop"] != 6 {
+        t.Errorf("loop executed %d times, want 6", counts["loop"])
+    }
+    if counts["after"] != 1 {
+        t.Errorf("after executed %d times, want 1", counts["after"])
+    }
+}
+
+func TestNamed_ReplaceConcurrently(t *testing.T) {
+    tw := timing.NewMockWheel()
+    named := tw.Named()
+    var executed atomic.Int32
+    var wg sync.WaitGroup
+    for i := 0; i < 100; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            task := timing.TaskFN(func() {
+                executed.Add(1)
+            })
+            switch i % 3 {
+            case 0:
+                named.After("job", time.Second, task)
+            case 1:
+                named.Loop("job", time.Second, timing.NewLoopTask(time.Second, 1, task))
+            default:
+                if err := named.Cron("job", "@every 1s", task); err != nil {
+                    t.Error(err)
+                }
+            }
+        }(i)
+    }
+    wg.Wait()
+
+    tw.Advance(time.Second)
+    if n := executed.Load(); n != 1 {
+        t.Errorf("executed %d tasks, want 1 since every registration replaced the previous one", n)
+    }
+}
+
+func TestNamed_IfAbsent(t *testing.T) {
+    tw := timing.NewMockWheel()
+    named := tw.Named()
+    var executed, added atomic.Int32
+    var wg sync.WaitGroup
+    for i := 0; i < 100; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if named.AfterIfAbsent("once", time.Second, timing.TaskFN(func() {
+                executed.Add(1)
+            })) {
+                added.Add(1)
+            }
+        }()
+    }
+    wg.Wait()
+
+    if n := added.Load(); n != 1 {
+        t.Fatalf("AfterIfAbsent() returned true %d times, want 1", n)
+    }
+    tw.Advance(time.Second)
+    if n := executed.Load(); n != 1 {
+        t.Errorf("executed %d tasks, want 1", n)
+    }
+
+    // 已执行的一次性任务不再占用名称
+    if !named.AfterIfAbsent("once", time.Second, timing.TaskFN(func() {})) {
+        t.Errorf("AfterIfAbsent() = false after the previous task fired, want true")
+    }
+
+    if added, err := named.CronIfAbsent("report", "@hourly", timing.TaskFN(func() {})); !added || err != nil {
+        t.Fatalf("CronIfAbsent() = %v, %v, want true, nil", added, err)
+    }
+    if added, err := named.CronIfAbsent("report", "@daily", timing.TaskFN(func() {})); added || err != nil {
+        t.Errorf("CronIfAbsent() on a registered name = %v, %v, want false, nil", added, err)
+    }
+    if named.LoopIfAbsent("report", time.Second, timing.NewForeverLoopTask(time.Second, timing.TaskFN(func() {}))) {
+        t.Errorf("LoopIfAbsent() on a registered name = true, want false")
+    }
+    named.Stop("report")
+    if !named.LoopIfAbsent("report", time.Second, timing.NewForeverLoopTask(time.Second, timing.TaskFN(func() {}))) {
+        t.Errorf("LoopIfAbsent() after Stop = false, want true")
+    }
+}
+
+func TestNamed_StopWait(t *testing.T) {
+    named := timing.New().Named()
+
+    var executions atomic.Int32
+    var finished atomic.Bool
+    started := make(chan struct{}, 1)
+    named.Loop("long", 0, timing.NewForeverLoopTask(10*time.Millisecond, timing.TaskFN(func() {
+        executions.Add(1)
+        select {
+        case started <- struct{}{}:
+        default:
+        }
+        time.Sleep(100 * time.Millisecond)
+        finished.Store(true)
+    })))
+
+    select {
+    case <-started:
+    case <-time.After(time.Second):
+        t.Fatalf("loop task did not start")
+    }
+    if err := named.StopWait("long", context.Background()); err != nil {
+        t.Fatalf("StopWait() error = %v", err)
+    }
+    if !finished.Load() {
+        t.Errorf("StopWait() returned before the in-flight execution finished")
+    }
+    n := executions.Load()
+    time.Sleep(50 * time.Millisecond)
+    if executions.Load() != n {
+        t.Errorf("task executed %d more times after StopWait", executions.Load()-n)
+    }
+
+    // ctx 被取消时立即返回，正在进行的执行不受影响
+    release := make(chan struct{})
+    running := make(chan struct{})
+    named.After("blocked", 0, timing.TaskFN(func() {
+        close(running)
+        <-release
+    }))
+    <-running
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+    defer cancel()
+    if err := named.StopWait("blocked", ctx); !errors.Is(err, context.DeadlineExceeded) {
+        t.Errorf("StopWait() with an expired context error = %v, want context.DeadlineExceeded", err)
+    }
+    close(release)
+
+    if err := named.StopWait("missing", context.Background()); err != nil {
+        t.Errorf("StopWait() on a missing name error = %v, want nil", err)
+    }
+}
+
+func TestNamed_ErrTask(t *testing.T) {
+    var errs []string
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithErrorHandler(func(err error) {
+            errs = append(errs, err.Error())
+        })
+    }))
+    named := tw.Named()
+    failing := func(name string) timing.Task {
+        return timing.NewErrTask(timing.ErrTaskFN(func() error {
+            return errors.New(name)
+        }))
+    }
+
+    named.After("after", time.Second, failing("after"))
+    named.Loop("loop", time.Second, timing.NewLoopTask(time.Second, 1, failing("loop")))
+    if err := named.Cron("cron", "@every 1s", failing("cron")); err != nil {
+        t.Fatal(err)
+    }
+    tw.Advance(time.Second)
+    named.Clear()
+
+    sort.Strings(errs)
+    if want := []string{"after", "cron", "loop"}; !reflect.DeepEqual(errs, want) {
+        t.Errorf("handled errors = %v, want %v", errs, want)
+    }
+}
+
+func TestNamed_ImmediateSyncReentrant(t *testing.T) {
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithImmediateSync()
+    }))
+    named := tw.Named()
+
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        named.After("outer", 0, timing.TaskFN(func() {
+            // 同步执行的任务中调用 Named 的方法不应发生死锁
+            named.After("inner", time.Hour, timing.TaskFN(func() {}))
+            named.Stop("inner")
+        }))
+    }()
+
+    select {
+    case <-done:
+    case <-time.After(2 * time.Second):
+        t.Fatalf("Named.After deadlocked when the immediate task called Named")
+    }
+}
+
+func TestNamed_IfAbsentImmediateRace(t *testing.T) {
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithImmediateSync()
+    }))
+    named := tw.Named()
+
+    started, release := make(chan struct{}), make(chan struct{})
+    result := make(chan bool, 1)
+    go func() {
+        result <- named.AfterIfAbsent("job", 0, timing.TaskFN(func() {
+            close(started)
+            <-release
+        }))
+    }()
+
+    // 立即执行的任务仍在运行时，名称应当已被占用，长延迟的同名任务不应被注册
+    <-started
+    var delayed atomic.Bool
+    if named.AfterIfAbsent("job", time.Hour, timing.TaskFN(func() {
+        delayed.Store(true)
+    })) {
+        t.Errorf("AfterIfAbsent(time.Hour) = true while the immediate task held the name, want false")
+    }
+    close(release)
+
+    select {
+    case added := <-result:
+        if !added {
+            t.Errorf("AfterIfAbsent(0) = false although its task ran, want true")
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatalf("AfterIfAbsent(0) did not return")
+    }
+}
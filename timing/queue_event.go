@@ -0,0 +1,26 @@
+package timing
+
+import "time"
+
+// QueueEventKind 定义了 QueueEvent 所描述的延迟队列行为
+type QueueEventKind int
+
+const (
+    QueueEventKindSleep   QueueEventKind = iota // QueueEventKindSleep 表示处理协程开始等待最近的计时桶到期，Delay 为计划等待的时长
+    QueueEventKindWakeup                        // QueueEventKindWakeup 表示处理协程结束等待，Delay 为相对于计划唤醒时间的偏差，负值表示因新的计时桶加入而被提前唤醒
+    QueueEventKindProcess                       // QueueEventKindProcess 表示到期的计时桶被处理，Delay 为处理时相对于计时桶过期时间的延迟
+    QueueEventKindRefresh                       // QueueEventKindRefresh 表示计时桶的过期时间发生变化，队列被要求重新检查最近的计时桶，Delay 始终为 0
+)
+
+// QueueEvent 描述了时间轮内部延迟队列的一次行为，通过 WithQueueTrace 设置的跟踪函数接收。
+//
+// 当计时器的执行时间晚于预期时，可以通过 QueueEventKindWakeup 的 Delay 判断延迟队列是否按时唤醒，
+// 并通过 QueueEventKindProcess 的 Delay 判断延迟发生在唤醒之前还是之后。
+//
+// 关键行为说明：
+//  - Time 及 Delay 的精度均为毫秒，Time 来源于 WithClock 设置的时间源
+type QueueEvent struct {
+    Kind  QueueEventKind // 事件类型
+    Time  time.Time      // 事件发生的时间
+    Delay time.Duration  // 与事件类型相关的时长，详见 QueueEventKind
+}
@@ -0,0 +1,343 @@
+package timing
+
+import (
+    "fmt"
+    "github.com/gorhill/cronexpr"
+    "github.com/kercylan98/chrono"
+    "strings"
+    "time"
+)
+
+// cronDescriptors 是 cron 预定义描述符到等价表达式的映射，以确保其行为不依赖于底层库的实现
+var cronDescriptors = map[string]string{
+    "@yearly":   "0 0 0 1 1 * *",
+    "@annually": "0 0 0 1 1 * *",
+    "@monthly":  "0 0 0 1 * * *",
+    "@weekly":   "0 0 0 * * 0 *",
+    "@daily":    "0 0 0 * * * *",
+    "@midnight": "0 0 0 * * * *",
+    "@hourly":   "0 0 * * * * *",
+}
+
+// Schedule 定义了任务的调度策略，它决定了任务在给定时间之后的下一次执行时间。
+//
+// Schedule 是 After、Loop 与 Cron 等调度机制的统一抽象，通过 Wheel.Schedule 可以将任意调度策略与任务组合，
+// 使调用方能够通过配置等方式多态地选择调度策略。由于签名一致，任意 LoopTask 同样可以作为 Schedule 使用。
+//
+// 关键行为说明：
+//  - Next 返回 StopLoop 时，任务将被停止
+//  - Next 返回的时间不晚于 after 时，任务同样将被停止
+//
+// 使用建议：
+//  - 内置的 CronSchedule、IntervalSchedule 与 CalendarSchedule 覆盖了常见的调度场景
+//  - 自定义实现应确保 Next 方法是线程安全的
+type Schedule interface {
+    // Next 返回在 after 之后的下一次执行时间，返回 StopLoop 时表示停止调度
+    Next(after time.Time) time.Time
+}
+
+// ScheduleFN 是 Schedule 的函数式实现
+type ScheduleFN func(after time.Time) time.Time
+
+func (f ScheduleFN) Next(after time.Time) time.Time {
+    return f(after)
+}
+
+// DSTPolicy 定义了 cron 表达式命中因夏令时切换而不存在的墙上时间时的处理策略。
+//
+// 例如在 America/New_York 时区，2024-03-10 的 02:00 将直接拨快至 03:00，此时 "每天 02:30" 在当天并不存在。
+type DSTPolicy int
+
+const (
+    DSTPolicyFireNext         DSTPolicy = iota // 按照被跳过的时长向后顺延，例如不存在的 02:30 将在 03:30 执行，这是默认策略
+    DSTPolicySkipNonexistent                   // 跳过当次执行，等待下一个存在的墙上时间
+    DSTPolicyFirePrevious                      // 按照被跳过的时长向前提前，例如不存在的 02:30 将在 01:30 执行
+)
+
+// CronSchedule 通过 cron 表达式创建一个调度策略。
+//
+// 参数 expr 是一个标准的 cron 表达式，当表达式无效时将返回包装了 ErrInvalidCron 的错误。
+// 表达式将基于传入 Next 的时间所在的时区进行计算。
+// 可选参数 policy 用于指定表达式命中因夏令时切换而不存在的墙上时间时的处理策略，默认为 DSTPolicyFireNext。
+//
+// 除标准表达式外，还支持以下预定义描述符：
+//  - @yearly（@annually）、@monthly、@weekly、@daily（@midnight）、@hourly，它们将被转换为等价的表达式
+//  - @every <duration>，例如 "@every 5m"，等价于以 time.ParseDuration 解析的间隔创建的 IntervalSchedule，不受 policy 影响
+//
+// 关键行为说明：
+//  - 无法识别的以 @ 开头的描述符将返回错误
+//  - 因夏令时回拨而重复出现的墙上时间（例如秋季回拨的 01:30）仅会在首次出现时触发一次
+func CronSchedule(expr string, policy ...DSTPolicy) (Schedule, error) {
+    expr = strings.TrimSpace(expr)
+    if strings.HasPrefix(expr, "@") {
+        if every, ok := strings.CutPrefix(expr, "@every "); ok {
+            d, err := time.ParseDuration(strings.TrimSpace(every))
+            if err != nil {
+                return nil, fmt.Errorf("timing: %w %q: %w", ErrInvalidCron, expr, err)
+            }
+            if d <= 0 {
+                return nil, fmt.Errorf("timing: %w %q: non-positive interval", ErrInvalidCron, expr)
+            }
+            return IntervalSchedule(d), nil
+        }
+        normalized, ok := cronDescriptors[expr]
+        if !ok {
+            return nil, fmt.Errorf("timing: %w %q: unrecognized descriptor", ErrInvalidCron, expr)
+        }
+        expr = normalized
+    }
+
+    expression, err := cronexpr.Parse(expr)
+    if err != nil {
+        return nil, fmt.Errorf("timing: %w %q: %w", ErrInvalidCron, expr, err)
+    }
+    var dst DSTPolicy
+    if len(policy) > 0 {
+        dst = policy[0]
+    }
+    return ScheduleFN(func(after time.Time) time.Time {
+        return cronNext(expression, after, dst)
+    }), nil
+}
+
+// CronNext 解析 cron 表达式并返回其在 after 之后的下一次执行时间，适用于校验用户输入的表达式及预览调度计划等场景。
+//
+// 表达式的解析规则与 CronSchedule 一致，当表达式无效时返回错误。
+// 当表达式不存在 after 之后的执行时间时，返回零值时间，即 StopLoop。
+func CronNext(expr string, after time.Time) (time.Time, error) {
+    schedule, err := CronSchedule(expr)
+    if err != nil {
+        return time.Time{}, err
+    }
+    return schedule.Next(after), nil
+}
+
+// CronSchedulePreview 解析 cron 表达式并返回其在 from 之后的至多 n 次执行时间，适用于在界面中展示 "接下来 5 次执行时间" 等场景。
+//
+// 表达式仅会被解析一次，其解析规则与 CronSchedule 一致，当表达式无效时将在计算任何执行时间之前返回错误。
+//
+// 关键行为说明：
+//  - 返回的执行时间严格递增，且均位于 from 所在的时区中
+//  - 当表达式不存在更多的执行时间时，返回的切片长度将小于 n
+//  - 当 n 小于等于 0 时，返回空切片
+func CronSchedulePreview(expr string, from time.Time, n int) ([]time.Time, error) {
+    schedule, err := CronSchedule(expr)
+    if err != nil {
+        return nil, err
+    }
+    times := make([]time.Time, 0, max(n, 0))
+    for previous := from; len(times) < n; {
+        next := schedule.Next(previous)
+        if IsStop(next) || !next.After(previous) {
+            break
+        }
+        times = append(times, next)
+        previous = next
+    }
+    return times, nil
+}
+
+// PreviewSchedule 模拟循环任务的调度并返回其在 from 之后的至多 n 次执行时间，而不会实际调度或执行任务，与 CronSchedulePreview 相对应。
+//
+// 首次执行时间为 task.Next(from)，此后每次以上一次的执行时间调用 task.Next，当其返回 StopLoop 或不晚于上一次执行时间的时间时提前停止，
+// 这与 Wheel.Loop 停止任务的规则一致。
+//
+// 关键行为说明：
+//  - 对于通过 NewLoopTask 创建的任务，将按照其间隔及剩余的执行次数进行模拟，不受当前时间的影响，且不会修改任务的剩余执行次数
+//  - 对于其他 LoopTask，将直接调用其 Next 方法，若 Next 依赖于执行结果或具有副作用（例如 Repeat 所使用的任务），预览结果可能与实际调度不一致
+//  - 当 n 小于等于 0 时，返回空切片
+func PreviewSchedule(task LoopTask, from time.Time, n int) []time.Time {
+    next := task.Next
+    if loop, ok := task.(*loopTask); ok {
+        remaining := loop.times.Load()
+        next = func(previous time.Time) time.Time {
+            if remaining == 0 {
+                return StopLoop
+            }
+            if remaining > 0 {
+                remaining--
+            }
+            return previous.Add(loop.interval)
+        }
+    }
+
+    times := make([]time.Time, 0, max(n, 0))
+    for previous := from; len(times) < n; {
+        current := next(previous)
+        if IsStop(current) || !current.After(previous) {
+            break
+        }
+        times = append(times, current)
+        previous = current
+    }
+    return times
+}
+
+// cronNext 计算 cron 表达式在 after 之后的下一次执行时间。
+//
+// 表达式在不受夏令时影响的 UTC 墙上时间中进行计算，随后再映射回 after 所在的时区，
+// 以避免底层库在不存在的墙上时间上返回不晚于 after 的时间，进而导致任务被意外停止。
+func cronNext(expression *cronexpr.Expression, after time.Time, policy DSTPolicy) time.Time {
+    location := after.Location()
+    wall := wallClock(after, time.UTC)
+    for {
+        wall = expression.Next(wall)
+        if wall.IsZero() {
+            return StopLoop
+        }
+        next, exist := resolveWallClock(wall, location, policy)
+        if exist && next.After(after) {
+            return next
+        }
+    }
+}
+
+// wallClock 返回与 t 具有相同墙上时间的、位于 location 中的时间
+func wallClock(t time.Time, location *time.Location) time.Time {
+    return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
+}
+
+// resolveWallClock 将 UTC 中表示的墙上时间 wall 映射为 location 中的时间，当该墙上时间因夏令时切换而不存在时按照 policy 处理。
+//
+// 当 policy 为 DSTPolicySkipNonexistent 且墙上时间不存在时，返回的 exist 为 false。
+func resolveWallClock(wall time.Time, location *time.Location, policy DSTPolicy) (t time.Time, exist bool) {
+    t = wallClock(wall, location)
+    if wallClock(t, time.UTC).Equal(wall) {
+        return t, true
+    }
+
+    // 分别以切换前后的时区偏移解释该墙上时间，两者之差即为被跳过的时长
+    _, before := wall.Add(-12 * time.Hour).In(location).Zone()
+    _, after := wall.Add(12 * time.Hour).In(location).Zone()
+    switch policy {
+    case DSTPolicySkipNonexistent:
+        return time.Time{}, false
+    case DSTPolicyFirePrevious:
+        return wall.Add(-time.Duration(after) * time.Second).In(location), true
+    default:
+        return wall.Add(-time.Duration(before) * time.Second).In(location), true
+    }
+}
+
+// IntervalSchedule 创建一个以固定间隔重复执行的调度策略。
+//
+// 参数 d 定义了两次执行之间的间隔时间，当 d 小于等于 0 时，将以时间轮可表示的最小间隔 Millisecond 作为间隔。
+func IntervalSchedule(d time.Duration) Schedule {
+    if d <= 0 {
+        d = Millisecond
+    }
+    return ScheduleFN(func(after time.Time) time.Time {
+        return after.Add(d)
+    })
+}
+
+// CalendarSchedule 创建一个在每个时间单位起始点执行的调度策略。
+//
+// 参数 unit 指定了时间单位，例如 chrono.UnitDay 表示在每天的零点执行，chrono.UnitMonday 表示在每周一的零点执行。
+// 起始点的计算规则与 chrono.NextStartOf 一致，当 unit 为定义外的单位时将返回错误。
+//
+// 关键行为说明：
+//  - 月、年及星期等日历单位按照日历进行推进，不受月份天数及夏令时的影响
+//  - 起始点基于传入 Next 的时间所在的时区进行计算
+func CalendarSchedule(unit chrono.Unit) (Schedule, error) {
+    if _, err := chrono.StartOfE(time.Time{}, unit); err != nil {
+        return nil, fmt.Errorf("timing: calendar schedule: %w", err)
+    }
+    return ScheduleFN(func(after time.Time) time.Time {
+        return chrono.NextStartOf(after, unit)
+    }), nil
+}
+
+// maxExcludedRuns 是 ExcludeDates 连续跳过的执行时间的上限，超过该上限时将视为不存在下一次执行时间
+const maxExcludedRuns = 10000
+
+// ExcludeDates 包装 inner 调度策略，跳过所有 skip 返回 true 的执行时间，适用于 "每天执行但节假日除外" 等场景。
+//
+// Next 将持续调用 inner.Next 直至得到 skip 返回 false 的执行时间，skip 接收的时间即为 inner.Next 返回的执行时间。
+//
+// 关键行为说明：
+//  - 连续跳过的执行时间超过 10000 次时将返回 StopLoop，以避免 skip 始终返回 true 时陷入死循环
+//  - inner 返回 StopLoop 或不晚于上一次执行时间的时间时将直接返回，任务随之停止
+//  - 当 skip 为 nil 时，将直接返回 inner
+func ExcludeDates(inner Schedule, skip func(t time.Time) bool) Schedule {
+    if skip == nil {
+        return inner
+    }
+    return ScheduleFN(func(after time.Time) time.Time {
+        for i := 0; i < maxExcludedRuns; i++ {
+            next := inner.Next(after)
+            if IsStop(next) || !next.After(after) || !skip(next) {
+                return next
+            }
+            after = next
+        }
+        return StopLoop
+    })
+}
+
+// WindowSchedule 包装 inner 调度策略，使其仅在 window 的开始时间（含）与结束时间（含）之间执行，适用于 "活动期间每小时执行" 等场景。
+//
+// 当 after 早于 window 的开始时间时，将从开始时间起计算首次执行时间，因此开始之前的执行时间均会被跳过。
+//
+// 关键行为说明：
+//  - 与 chrono.Period.Between 一致，window 为闭区间，恰好位于开始时间或结束时间的执行时间同样有效
+//  - 下一次执行时间晚于 window 的结束时间时将返回 StopLoop，任务随之停止
+//  - 传入 inner.Next 的时间始终位于 after 所在的时区中，不受 window 所在时区的影响
+func WindowSchedule(inner Schedule, window chrono.Period) Schedule {
+    start := window.Start().Add(-time.Nanosecond)
+    return ScheduleFN(func(after time.Time) time.Time {
+        if after.Before(start) {
+            after = start.In(after.Location())
+        }
+        next := inner.Next(after)
+        if IsStop(next) || next.After(window.End()) {
+            return StopLoop
+        }
+        return next
+    })
+}
+
+// WeekdayIntervalSchedule 创建一个每隔 weeks 周在 weekday 的 hour:min:sec 执行的调度策略，例如 "每隔一周的周二 10:00"。
+//
+// 参数 anchor 用于确定周的奇偶性：anchor 当天或之后的第一个 weekday 即为首个有效日期，此后每隔 weeks 周的同一天均为有效日期。
+// 当 weeks 小于 1、weekday 不是有效的星期或时刻超出一天的范围时将返回错误。
+//
+// 关键行为说明：
+//  - 日期按照日历推进而非固定时长，因此跨越夏令时切换后仍将在相同的墙上时间执行
+//  - 日期及时刻基于传入 Next 的时间所在的时区进行计算，anchor 同样将被转换至该时区后取其日期
+//  - 不存在的墙上时间（例如春季拨快时的 02:30）将按照 time.Date 的规则进行规范化
+func WeekdayIntervalSchedule(anchor time.Time, weekday time.Weekday, weeks int, hour, min, sec int) (Schedule, error) {
+    switch {
+    case weeks < 1:
+        return nil, fmt.Errorf("timing: weekday interval schedule: non-positive weeks %d", weeks)
+    case weekday < time.Sunday || weekday > time.Saturday:
+        return nil, fmt.Errorf("timing: weekday interval schedule: invalid weekday %d", weekday)
+    case hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 59:
+        return nil, fmt.Errorf("timing: weekday interval schedule: invalid time of day %02d:%02d:%02d", hour, min, sec)
+    }
+    period := int64(weeks) * 7
+    return ScheduleFN(func(after time.Time) time.Time {
+        location := after.Location()
+        local := anchor.In(location)
+        ay, am, ad := local.Date()
+        first := civilDay(ay, am, ad) + (int64(weekday)-int64(local.Weekday())+7)%7
+
+        y, m, d := after.Date()
+        var k int64
+        if day := civilDay(y, m, d); day > first {
+            k = (day - first + period - 1) / period
+        }
+        for {
+            offset := int(first + k*period - civilDay(ay, am, ad))
+            if next := time.Date(ay, am, ad+offset, hour, min, sec, 0, location); next.After(after) {
+                return next
+            }
+            k++
+        }
+    }), nil
+}
+
+// civilDay 返回日期自 Unix 纪元以来的天数，计算不受时区及夏令时的影响
+func civilDay(year int, month time.Month, day int) int64 {
+    return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
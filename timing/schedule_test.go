@@ -0,0 +1,591 @@
+package timing_test
+
+import (
+    "errors"
+    "github.com/kercylan98/chrono"
+    "github.com/kercylan98/chrono/timing"
+    "reflect"
+    "strings"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestCalendarSchedule(t *testing.T) {
+    now := time.Date(2023, 10, 1, 12, 1, 1, 0, time.Local)
+    tests := []struct {
+        name     string
+        unit     chrono.Unit
+        expected time.Time
+    }{
+        {name: "Hour", unit: chrono.UnitHour, expected: time.Date(2023, 10, 1, 13, 0, 0, 0, time.Local)},
+        {name: "Day", unit: chrono.UnitDay, expected: time.Date(2023, 10, 2, 0, 0, 0, 0, time.Local)},
+        {name: "Week", unit: chrono.UnitWeek, expected: time.Date(2023, 10, 2, 0, 0, 0, 0, time.Local)},
+        {name: "Sunday", unit: chrono.UnitSunday, expected: time.Date(2023, 10, 8, 0, 0, 0, 0, time.Local)},
+        {name: "Month", unit: chrono.UnitMonth, expected: time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)},
+        {name: "Year", unit: chrono.UnitYear, expected: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            schedule, err := timing.CalendarSchedule(tt.unit)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if result := schedule.Next(now); !result.Equal(tt.expected) {
+                t.Errorf("Next() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+
+    if _, err := timing.CalendarSchedule(chrono.Unit(-100)); err == nil {
+        t.Errorf("CalendarSchedule() error = nil, want error")
+    }
+}
+
+func TestCronSchedule(t *testing.T) {
+    now := time.Date(2023, 10, 1, 12, 1, 1, 0, time.Local)
+    tests := []struct {
+        name     string
+        expr     string
+        expected time.Time
+    }{
+        {name: "Every", expr: "@every 250ms", expected: now.Add(250 * time.Millisecond)},
+        {name: "Hourly", expr: "@hourly", expected: time.Date(2023, 10, 1, 13, 0, 0, 0, time.Local)},
+        {name: "Daily", expr: "@daily", expected: time.Date(2023, 10, 2, 0, 0, 0, 0, time.Local)},
+        {name: "Weekly", expr: "@weekly", expected: time.Date(2023, 10, 8, 0, 0, 0, 0, time.Local)},
+        {name: "Monthly", expr: "@monthly", expected: time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            schedule, err := timing.CronSchedule(tt.expr)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if result := schedule.Next(now); !result.Equal(tt.expected) {
+                t.Errorf("Next() = %v, want %v", result, tt.expected)
+            }
+        })
+    }
+
+    for _, expr := range []string{"@unknown", "@every", "@every -1s", "not a cron"} {
+        if _, err := timing.CronSchedule(expr); !errors.Is(err, timing.ErrInvalidCron) {
+            t.Errorf("CronSchedule(%q) error = %v, want ErrInvalidCron", expr, err)
+        }
+    }
+}
+
+func TestIntervalSchedule(t *testing.T) {
+    now := time.Date(2023, 10, 1, 12, 1, 1, 0, time.Local)
+    tests := []struct {
+        name     string
+        interval time.Duration
+        expected time.Time
+    }{
+        {name: "Positive", interval: 90 * time.Minute, expected: now.Add(90 * time.Minute)},
+        {name: "Zero", interval: 0, expected: now.Add(timing.Millisecond)},
+        {name: "Negative", interval: -time.Second, expected: now.Add(timing.Millisecond)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            schedule := timing.IntervalSchedule(tt.interval)
+            if result := schedule.Next(now); !result.Equal(tt.expected) {
+                t.Errorf("Next() = %v, want %v", result, tt.expected)
+            }
+            // 间隔保持不变，后续的执行时间同样以上一次的时间为基准
+            if following := tt.expected.Add(tt.expected.Sub(now)); !schedule.Next(tt.expected).Equal(following) {
+                t.Errorf("Next() = %v, want %v", schedule.Next(tt.expected), following)
+            }
+        })
+    }
+}
+
+func TestWheel_Schedule(t *testing.T) {
+    start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start)).WithLocation(time.UTC)
+    }))
+
+    // ScheduleFN 在第三次执行后返回 StopLoop，任务应当随之停止
+    var calls []time.Time
+    var executed int
+    timer := tw.Schedule(timing.ScheduleFN(func(after time.Time) time.Time {
+        calls = append(calls, after)
+        if len(calls) > 3 {
+            return timing.StopLoop
+        }
+        return after.Add(time.Minute)
+    }), timing.TaskFN(func() {
+        executed++
+    }))
+
+    tw.Advance(time.Hour)
+    if executed != 3 {
+        t.Errorf("executed %d times, want 3", executed)
+    }
+    if !timer.Stopped() {
+        t.Errorf("Stopped() = false after the schedule returned StopLoop, want true")
+    }
+    for i, after := range calls {
+        if expected := start.Add(time.Duration(i) * time.Minute); !after.Equal(expected) {
+            t.Errorf("Next() call %d received %v, want %v", i, after, expected)
+        }
+    }
+
+    // 不晚于上一次执行时间的结果同样会停止任务
+    backwards := tw.Schedule(timing.ScheduleFN(func(after time.Time) time.Time {
+        return after
+    }), timing.TaskFN(func() {
+        t.Errorf("task ran although the schedule never advanced")
+    }))
+    tw.Advance(time.Hour)
+    if !backwards.Stopped() {
+        t.Errorf("Stopped() = false for a schedule that does not advance, want true")
+    }
+}
+
+func TestWheel_CronInvalid(t *testing.T) {
+    tw := timing.New()
+    _, err := tw.Cron("garbage * expression", timing.TaskFN(func() {}))
+    if !errors.Is(err, timing.ErrInvalidCron) {
+        t.Fatalf("Cron() error = %v, want ErrInvalidCron", err)
+    }
+    if !strings.Contains(err.Error(), "garbage * expression") {
+        t.Errorf("Cron() error = %q, want it to carry the expression", err)
+    }
+}
+
+func TestWheel_CronEvery(t *testing.T) {
+    tw := timing.New()
+    var count atomic.Int32
+    timer, err := tw.Cron("@every 250ms", timing.TaskFN(func() {
+        count.Add(1)
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer timer.Stop()
+
+    time.Sleep(1100 * time.Millisecond)
+    if n := count.Load(); n < 3 || n > 5 {
+        t.Errorf("executed %d times, want 4", n)
+    }
+}
+
+func TestWheel_CronSubTickInterval(t *testing.T) {
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    for _, tt := range []struct {
+        name     string
+        schedule func(tw timing.Wheel, task timing.Task) timing.Timer
+    }{
+        {"Cron", func(tw timing.Wheel, task timing.Task) timing.Timer {
+            timer, err := tw.Cron("@every 100us", task)
+            if err != nil {
+                t.Fatal(err)
+            }
+            return timer
+        }},
+        {"IntervalSchedule", func(tw timing.Wheel, task timing.Task) timing.Timer {
+            return tw.Schedule(timing.IntervalSchedule(100*time.Microsecond), task)
+        }},
+    } {
+        t.Run(tt.name, func(t *testing.T) {
+            tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+                config.WithClock(timing.NewManualClock(start)).WithTick(10 * time.Millisecond)
+            }))
+            var fired []time.Time
+            timer := tt.schedule(tw, timing.TaskFN(func() {
+                fired = append(fired, tw.Now())
+            }))
+            if expected := start.Add(10 * time.Millisecond); !timer.ExpiresAt().Equal(expected) {
+                t.Errorf("ExpiresAt() = %v, want the first tick %v", timer.ExpiresAt(), expected)
+            }
+
+            tw.Advance(100 * time.Millisecond)
+            if len(fired) != 10 {
+                t.Fatalf("sub-tick schedule fired %d times in 100ms, want 10", len(fired))
+            }
+            for i := 1; i < len(fired); i++ {
+                if interval := fired[i].Sub(fired[i-1]); interval != 10*time.Millisecond {
+                    t.Errorf("interval between executions %d and %d = %v, want the 10ms tick", i-1, i, interval)
+                }
+            }
+        })
+    }
+}
+
+func TestCronSchedule_DSTPolicy(t *testing.T) {
+    location, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        t.Skip(err)
+    }
+    // 2024-03-10 02:00 拨快至 03:00，当天的 02:30 并不存在
+    now := time.Date(2024, 3, 9, 3, 0, 0, 0, location)
+    following := time.Date(2024, 3, 11, 2, 30, 0, 0, location)
+    tests := []struct {
+        name     string
+        policy   timing.DSTPolicy
+        expected time.Time
+    }{
+        {name: "FireNext", policy: timing.DSTPolicyFireNext, expected: time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)},
+        {name: "SkipNonexistent", policy: timing.DSTPolicySkipNonexistent, expected: following},
+        {name: "FirePrevious", policy: timing.DSTPolicyFirePrevious, expected: time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            schedule, err := timing.CronSchedule("0 30 2 * * * *", tt.policy)
+            if err != nil {
+                t.Fatal(err)
+            }
+            next := schedule.Next(schedule.Next(now))
+            if first := schedule.Next(now); !first.Equal(tt.expected) {
+                t.Errorf("Next() = %v, want %v", first, tt.expected)
+            } else if !tt.expected.Equal(following) && !next.Equal(following) {
+                t.Errorf("Next() after the transition = %v, want %v", next, following)
+            }
+        })
+    }
+}
+
+func TestWheel_CronDSTPolicy(t *testing.T) {
+    location, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        t.Skip(err)
+    }
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(time.Date(2024, 3, 8, 12, 0, 0, 0, location))).
+            WithLocation(location).
+            WithDSTPolicy(timing.DSTPolicySkipNonexistent)
+    }))
+    var executed []time.Time
+    if _, err = tw.Cron("0 30 2 * * * *", timing.TaskFN(func() {
+        executed = append(executed, tw.Now())
+    })); err != nil {
+        t.Fatal(err)
+    }
+
+    // 跨越 2024-03-10 的夏令时切换进行推进，被跳过的当天不应执行，且任务不应因此停止
+    tw.Advance(time.Date(2024, 3, 12, 12, 0, 0, 0, location).Sub(tw.Now()))
+    var days []int
+    for _, at := range executed {
+        days = append(days, at.In(location).Day())
+    }
+    if len(days) != 3 || days[0] != 9 || days[1] != 11 || days[2] != 12 {
+        t.Errorf("executed on days %v, want [9 11 12]", days)
+    }
+}
+
+func TestCronNext(t *testing.T) {
+    after := time.Date(2023, 10, 1, 12, 1, 1, 0, time.Local)
+    next, err := timing.CronNext("0 30 9 * * * *", after)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if expected := time.Date(2023, 10, 2, 9, 30, 0, 0, time.Local); !next.Equal(expected) {
+        t.Errorf("CronNext() = %v, want %v", next, expected)
+    }
+
+    for _, expr := range []string{"not a cron", "61 * * * *", "@unknown"} {
+        if _, err = timing.CronNext(expr, after); err == nil {
+            t.Errorf("CronNext(%q) error = nil, want error", expr)
+        }
+    }
+}
+
+func TestCronSchedulePreview(t *testing.T) {
+    from := time.Date(2023, 10, 1, 12, 1, 1, 0, time.Local)
+    var tests = []struct {
+        name  string
+        expr  string
+        n     int
+        first time.Time
+        want  int
+    }{
+        {"Hourly", "@hourly", 5, time.Date(2023, 10, 1, 13, 0, 0, 0, time.Local), 5},
+        {"Daily", "0 30 9 * * * *", 3, time.Date(2023, 10, 2, 9, 30, 0, 0, time.Local), 3},
+        {"Every", "@every 90s", 4, from.Add(90 * time.Second), 4},
+        {"Exhausted", "0 0 0 1 1 * 2024", 5, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), 1},
+        {"Zero", "@hourly", 0, time.Time{}, 0},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            times, err := timing.CronSchedulePreview(tt.expr, from, tt.n)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(times) != tt.want {
+                t.Fatalf("CronSchedulePreview() returned %d times, want %d", len(times), tt.want)
+            }
+            if len(times) > 0 && !times[0].Equal(tt.first) {
+                t.Errorf("first time = %v, want %v", times[0], tt.first)
+            }
+            for i := 1; i < len(times); i++ {
+                if !times[i].After(times[i-1]) {
+                    t.Errorf("times[%d] = %v is not after times[%d] = %v", i, times[i], i-1, times[i-1])
+                }
+            }
+        })
+    }
+
+    times, err := timing.CronSchedulePreview("not a cron", from, 5)
+    if !errors.Is(err, timing.ErrInvalidCron) || times != nil {
+        t.Errorf("CronSchedulePreview() = %v, %v, want nil and ErrInvalidCron", times, err)
+    }
+}
+
+func TestPreviewSchedule(t *testing.T) {
+    from := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+    task := timing.TaskFN(func() {})
+
+    var tests = []struct {
+        name string
+        task timing.LoopTask
+        n    int
+        want int
+    }{
+        {"Bounded", timing.NewLoopTask(time.Minute, 3, task), 5, 3},
+        {"Forever", timing.NewForeverLoopTask(time.Minute, task), 5, 5},
+        {"Exhausted", timing.NewLoopTask(time.Minute, 0, task), 5, 0},
+        {"NonAdvancing", timing.NewForeverLoopTask(0, task), 5, 0},
+        {"Zero", timing.NewForeverLoopTask(time.Minute, task), 0, 0},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            times := timing.PreviewSchedule(tt.task, from, tt.n)
+            if len(times) != tt.want {
+                t.Fatalf("PreviewSchedule() returned %v, want %d times", times, tt.want)
+            }
+            for i, at := range times {
+                if expected := from.Add(time.Duration(i+1) * time.Minute); !at.Equal(expected) {
+                    t.Errorf("times[%d] = %v, want %v", i, at, expected)
+                }
+            }
+        })
+    }
+
+    // 预览不会消耗有限次数任务的剩余执行次数
+    var count int
+    bounded := timing.NewLoopTask(time.Minute, 2, timing.TaskFN(func() {
+        count++
+    }))
+    timing.PreviewSchedule(bounded, from, 5)
+    tw := timing.NewMockWheel()
+    tw.Loop(time.Minute, bounded)
+    tw.Advance(10 * time.Minute)
+    if count != 2 {
+        t.Errorf("previewed task executed %d times, want 2", count)
+    }
+    if remaining := timing.PreviewSchedule(bounded, from, 5); len(remaining) != 0 {
+        t.Errorf("PreviewSchedule() of a finished task = %v, want none", remaining)
+    }
+
+    // 自定义的 LoopTask 将直接调用其 Next 方法
+    custom := timing.PreviewSchedule(customLoop{step: time.Hour, until: from.Add(2 * time.Hour)}, from, 5)
+    if len(custom) != 2 || !custom[1].Equal(from.Add(2*time.Hour)) {
+        t.Errorf("PreviewSchedule() of custom task = %v, want 2 hourly times", custom)
+    }
+}
+
+// customLoop 是一个在 until 之前以 step 为间隔执行的 LoopTask
+type customLoop struct {
+    step  time.Duration
+    until time.Time
+}
+
+func (c customLoop) Execute() {}
+
+func (c customLoop) Next(previous time.Time) time.Time {
+    if next := previous.Add(c.step); !next.After(c.until) {
+        return next
+    }
+    return timing.StopLoop
+}
+
+func TestWheel_CronExpiresAt(t *testing.T) {
+    start := time.Date(2023, 10, 1, 12, 1, 1, 0, time.Local)
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start)).WithLocation(time.Local)
+    }))
+    timer, err := tw.Cron("@hourly", timing.TaskFN(func() {}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if expected := time.Date(2023, 10, 1, 13, 0, 0, 0, time.Local); !timer.ExpiresAt().Equal(expected) {
+        t.Errorf("ExpiresAt() = %v, want %v", timer.ExpiresAt(), expected)
+    }
+
+    tw.Advance(time.Hour)
+    if expected := time.Date(2023, 10, 1, 14, 0, 0, 0, time.Local); !timer.ExpiresAt().Equal(expected) {
+        t.Errorf("ExpiresAt() after firing = %v, want %v", timer.ExpiresAt(), expected)
+    }
+}
+
+func TestWheel_Location(t *testing.T) {
+    location := time.FixedZone("UTC+09:30", 9*3600+1800)
+    start := time.Date(2023, 10, 1, 5, 0, 0, 0, location)
+    if _, offset := start.In(time.Local).Zone(); offset == 9*3600+1800 {
+        t.Skip("time.Local has the same offset as the configured location")
+    }
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start.In(time.UTC))).WithLocation(location)
+    }))
+
+    // 时间源返回 UTC 时间，cron 表达式及日历调度仍应按照配置的时区而非 time.Local 计算
+    cron, err := tw.Cron("0 0 9 * * * *", timing.TaskFN(func() {}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if expected := time.Date(2023, 10, 1, 9, 0, 0, 0, location); !cron.ExpiresAt().Equal(expected) {
+        t.Errorf("Cron ExpiresAt() = %v, want %v", cron.ExpiresAt(), expected)
+    }
+
+    schedule, err := timing.CalendarSchedule(chrono.UnitDay)
+    if err != nil {
+        t.Fatal(err)
+    }
+    calendar := tw.Schedule(schedule, timing.TaskFN(func() {}))
+    if expected := time.Date(2023, 10, 2, 0, 0, 0, 0, location); !calendar.ExpiresAt().Equal(expected) {
+        t.Errorf("CalendarSchedule ExpiresAt() = %v, want %v", calendar.ExpiresAt(), expected)
+    }
+
+    tw.Advance(24 * time.Hour)
+    if expected := time.Date(2023, 10, 2, 9, 0, 0, 0, location); !cron.ExpiresAt().Equal(expected) {
+        t.Errorf("Cron ExpiresAt() after firing = %v, want %v", cron.ExpiresAt(), expected)
+    }
+    if expected := time.Date(2023, 10, 3, 0, 0, 0, 0, location); !calendar.ExpiresAt().Equal(expected) {
+        t.Errorf("CalendarSchedule ExpiresAt() after firing = %v, want %v", calendar.ExpiresAt(), expected)
+    }
+}
+
+func TestWheel_CronWindow(t *testing.T) {
+    at := func(hour, min int) time.Time {
+        return time.Date(2023, 10, 1, hour, min, 0, 0, time.UTC)
+    }
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(at(12, 1))).WithLocation(time.UTC)
+    }))
+
+    var fired []time.Time
+    timer, err := tw.CronWindow("@hourly", chrono.NewPeriod(at(14, 30), at(17, 0)), timing.TaskFN(func() {
+        fired = append(fired, tw.Now())
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !timer.ExpiresAt().Equal(at(15, 0)) {
+        t.Errorf("ExpiresAt() = %v, want %v", timer.ExpiresAt(), at(15, 0))
+    }
+
+    tw.Advance(at(14, 59).Sub(tw.Now()))
+    if len(fired) != 0 {
+        t.Fatalf("fired before the window started at %v", fired)
+    }
+
+    tw.Advance(3 * time.Hour)
+    if want := []time.Time{at(15, 0), at(16, 0), at(17, 0)}; !reflect.DeepEqual(fired, want) {
+        t.Errorf("CronWindow() fired at %v, want %v", fired, want)
+    }
+    if !timer.Stopped() {
+        t.Errorf("timer is not stopped after the window ended")
+    }
+
+    expired, err := tw.CronWindow("@hourly", chrono.NewPeriod(at(8, 0), at(10, 0)), timing.TaskFN(func() {}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !expired.Stopped() {
+        t.Errorf("timer with an ended window is not stopped")
+    }
+
+    if _, err := tw.CronWindow("invalid", chrono.NewPeriod(at(8, 0), at(10, 0)), timing.TaskFN(func() {})); !errors.Is(err, timing.ErrInvalidCron) {
+        t.Errorf("CronWindow() with invalid expression error = %v, want ErrInvalidCron", err)
+    }
+}
+
+func TestExcludeDates(t *testing.T) {
+    daily, err := timing.CalendarSchedule(chrono.UnitDay)
+    if err != nil {
+        t.Fatal(err)
+    }
+    weekend := func(t time.Time) bool {
+        return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+    }
+    schedule := timing.ExcludeDates(daily, weekend)
+
+    // 2023-10-05 为周四
+    next := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+    var days []int
+    for i := 0; i < 4; i++ {
+        next = schedule.Next(next)
+        days = append(days, next.Day())
+    }
+    if want := []int{6, 9, 10, 11}; !reflect.DeepEqual(days, want) {
+        t.Errorf("weekday-only daily schedule fired on days %v, want %v", days, want)
+    }
+
+    always := timing.ExcludeDates(daily, func(t time.Time) bool { return true })
+    if next := always.Next(time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)); !timing.IsStop(next) {
+        t.Errorf("Next() with every date excluded = %v, want StopLoop", next)
+    }
+}
+
+func TestWeekdayIntervalSchedule(t *testing.T) {
+    location, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        t.Skip(err)
+    }
+    // 2024-03-05 为周二，美国东部时间于 2024-03-10 进入夏令时，于 2024-11-03 退出夏令时
+    anchor := time.Date(2024, 3, 5, 0, 0, 0, 0, location)
+    schedule, err := timing.WeekdayIntervalSchedule(anchor, time.Tuesday, 2, 10, 0, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        name     string
+        after    time.Time
+        expected time.Time
+    }{
+        {"Before anchor", time.Date(2024, 2, 1, 0, 0, 0, 0, location), time.Date(2024, 3, 5, 10, 0, 0, 0, location)},
+        {"Anchor day", time.Date(2024, 3, 5, 9, 0, 0, 0, location), time.Date(2024, 3, 5, 10, 0, 0, 0, location)},
+        {"Spring forward", time.Date(2024, 3, 5, 10, 0, 0, 0, location), time.Date(2024, 3, 19, 10, 0, 0, 0, location)},
+        {"Off week", time.Date(2024, 3, 26, 9, 0, 0, 0, location), time.Date(2024, 4, 2, 10, 0, 0, 0, location)},
+        {"Fall back", time.Date(2024, 10, 29, 10, 0, 0, 0, location), time.Date(2024, 11, 12, 10, 0, 0, 0, location)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result := schedule.Next(tt.after)
+            if !result.Equal(tt.expected) {
+                t.Errorf("Next(%v) = %v, want %v", tt.after, result, tt.expected)
+            }
+            if result.Hour() != 10 || result.Weekday() != time.Tuesday {
+                t.Errorf("Next(%v) = %v, want a Tuesday at 10:00 wall clock", tt.after, result)
+            }
+        })
+    }
+
+    // 连续推进时应始终相隔两周，且保持相同的墙上时间
+    next := anchor
+    for i := 0; i < 30; i++ {
+        previous := next
+        next = schedule.Next(previous)
+        if i > 0 && (next.Hour() != 10 || chrono.NewPeriod(previous, next).CalendarDays() != 15) {
+            t.Fatalf("Next(%v) = %v, want two weeks later at 10:00", previous, next)
+        }
+    }
+
+    for _, weeks := range []int{0, -1} {
+        if _, err := timing.WeekdayIntervalSchedule(anchor, time.Tuesday, weeks, 10, 0, 0); err == nil {
+            t.Errorf("WeekdayIntervalSchedule(weeks = %d) error = nil, want error", weeks)
+        }
+    }
+    if _, err := timing.WeekdayIntervalSchedule(anchor, time.Tuesday, 1, 24, 0, 0); err == nil {
+        t.Errorf("WeekdayIntervalSchedule(hour = 24) error = nil, want error")
+    }
+}
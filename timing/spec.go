@@ -0,0 +1,28 @@
+package timing
+
+import "time"
+
+// SpecKind 定义了 ScheduleSpec 所描述的调度方式
+type SpecKind int
+
+const (
+    SpecKindCustom   SpecKind = iota // SpecKindCustom 表示通过自定义的 LoopTask、Schedule 或 Repeat 创建的调度，无法被序列化及重建
+    SpecKindOnce                     // SpecKindOnce 表示通过 After 或 At 创建的在绝对时间执行的一次性调度
+    SpecKindInterval                 // SpecKindInterval 表示通过 NewLoopTask 或 NewForeverLoopTask 创建的以固定间隔执行的循环调度
+    SpecKindCron                     // SpecKindCron 表示通过 Cron 创建的 cron 表达式调度
+)
+
+// ScheduleSpec 描述了计时器的调度方式，通过 Timer.Spec 获取，并可以通过 Wheel.ScheduleFromSpec 在重启后重建调度。
+//
+// ScheduleSpec 仅包含可导出的基础类型字段，可以直接通过 encoding/json 等方式进行持久化，任务本身需由调用方自行关联。
+//
+// 关键行为说明：
+//  - 仅与 Kind 对应的字段有效，其余字段为零值
+//  - 自定义调度的 Kind 为 SpecKindCustom，无法通过 Wheel.ScheduleFromSpec 重建
+type ScheduleSpec struct {
+    Kind      SpecKind      // 调度方式
+    At        time.Time     // SpecKindOnce 的计划执行时间，精度为毫秒
+    Interval  time.Duration // SpecKindInterval 的执行间隔
+    Cron      string        // SpecKindCron 的 cron 表达式
+    Remaining int           // SpecKindInterval 的剩余执行次数，负值表示不限次数
+}
@@ -0,0 +1,121 @@
+package timing_test
+
+import (
+    "encoding/json"
+    "errors"
+    "github.com/kercylan98/chrono/timing"
+    "testing"
+    "time"
+)
+
+// roundTrip 模拟将 ScheduleSpec 持久化后再读取
+func roundTrip(t *testing.T, spec timing.ScheduleSpec) timing.ScheduleSpec {
+    data, err := json.Marshal(spec)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var restored timing.ScheduleSpec
+    if err = json.Unmarshal(data, &restored); err != nil {
+        t.Fatal(err)
+    }
+    return restored
+}
+
+func TestScheduleSpec_Cron(t *testing.T) {
+    start := time.Date(2023, 10, 1, 12, 1, 1, 0, time.UTC)
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start)).WithLocation(time.UTC)
+    }))
+    timer, err := tw.Cron("@hourly", timing.TaskFN(func() {}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    spec := roundTrip(t, timer.Spec())
+    if spec.Kind != timing.SpecKindCron || spec.Cron != "@hourly" {
+        t.Fatalf("Spec() = %+v, want cron @hourly", spec)
+    }
+
+    // 重启后从新的当前时间重新计算下一次执行时间
+    restarted := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start.Add(3 * time.Hour))).WithLocation(time.UTC)
+    }))
+    var count int
+    restored, err := restarted.ScheduleFromSpec(spec, timing.TaskFN(func() {
+        count++
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if expected := time.Date(2023, 10, 1, 16, 0, 0, 0, time.UTC); !restored.ExpiresAt().Equal(expected) {
+        t.Errorf("restored ExpiresAt() = %v, want %v", restored.ExpiresAt(), expected)
+    }
+    restarted.Advance(2 * time.Hour)
+    if count != 2 {
+        t.Errorf("restored cron fired %d times in 2h, want 2", count)
+    }
+}
+
+func TestScheduleSpec_Interval(t *testing.T) {
+    tw := timing.NewMockWheel()
+    timer := tw.Loop(time.Second, timing.NewLoopTask(time.Second, 3, timing.TaskFN(func() {})))
+    tw.Advance(time.Second)
+
+    spec := roundTrip(t, timer.Spec())
+    if expected := (timing.ScheduleSpec{Kind: timing.SpecKindInterval, Interval: time.Second, Remaining: 2}); spec != expected {
+        t.Fatalf("Spec() = %+v, want %+v", spec, expected)
+    }
+
+    restarted := timing.NewMockWheel()
+    var count int
+    restored, err := restarted.ScheduleFromSpec(spec, timing.TaskFN(func() {
+        count++
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+    restarted.Advance(10 * time.Second)
+    if count != 2 {
+        t.Errorf("restored interval fired %d times, want the remaining 2", count)
+    }
+    if spec := restored.Spec(); spec.Remaining != 0 {
+        t.Errorf("restored Spec().Remaining = %d, want 0", spec.Remaining)
+    }
+}
+
+func TestScheduleSpec_Once(t *testing.T) {
+    tw := timing.NewMockWheel()
+    at := tw.Now().Add(time.Minute)
+    spec := roundTrip(t, tw.At(at, timing.TaskFN(func() {})).Spec())
+    if spec.Kind != timing.SpecKindOnce || !spec.At.Equal(at.Truncate(time.Millisecond)) {
+        t.Fatalf("Spec() = %+v, want once at %v", spec, at)
+    }
+
+    var fired bool
+    restored, err := tw.ScheduleFromSpec(spec, timing.TaskFN(func() {
+        fired = true
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !restored.ExpiresAt().Equal(spec.At) {
+        t.Errorf("restored ExpiresAt() = %v, want %v", restored.ExpiresAt(), spec.At)
+    }
+    tw.Advance(time.Minute)
+    if !fired {
+        t.Errorf("restored one-shot task did not fire")
+    }
+}
+
+func TestScheduleSpec_Custom(t *testing.T) {
+    tw := timing.NewMockWheel()
+    timer := tw.Repeat(time.Second, timing.RepeatTaskFN(func() (time.Duration, bool) {
+        return time.Second, true
+    }))
+    spec := timer.Spec()
+    if spec.Kind != timing.SpecKindCustom {
+        t.Fatalf("Spec().Kind = %v, want SpecKindCustom", spec.Kind)
+    }
+    if _, err := tw.ScheduleFromSpec(spec, timing.TaskFN(func() {})); !errors.Is(err, timing.ErrUnsupportedSpec) {
+        t.Errorf("ScheduleFromSpec() error = %v, want ErrUnsupportedSpec", err)
+    }
+}
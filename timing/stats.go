@@ -0,0 +1,16 @@
+package timing
+
+// Stats 描述了时间轮在某一时刻的运行状态快照。
+//
+// 通过 Wheel.Stats 获取，用于观测时间轮的负载情况。例如 "挂载了 10k 个计时器但仅有 3 个桶即将到期"
+// 与 "大量桶同时排队" 这两种截然不同的情况可以通过 Timers 与 PendingBuckets 的组合进行区分。
+//
+// 关键行为说明：
+//  - 快照中的各项数据分别采集，彼此之间不保证严格一致
+//  - Timers 包含了溢出轮中的计时器
+type Stats struct {
+    Name           string // 通过 WithName 设置的时间轮名称，用于聚合多个时间轮的指标
+    Timers         int    // 当前挂载在时间轮（含溢出轮）中的计时器数量
+    PendingBuckets int    // 延迟队列中等待到期的桶数量，反映了近期待处理的工作量
+    DroppedTasks   uint64 // 执行器因队列已满或已关闭而丢弃的任务数量，仅在使用 WithExecutorQueue、QueueExecutor 或 RateLimitExecutor 时有效
+}
@@ -1,6 +1,9 @@
 package timing
 
-import "time"
+import (
+    "sync/atomic"
+    "time"
+)
 
 // Task 定义了任务执行的基本接口。
 //
@@ -33,32 +36,133 @@ func (f TaskFN) Execute() {
     f()
 }
 
+// TimedTask 是一个可以获知自身计划执行时间的任务，时间轮在执行实现了该接口的任务时将优先调用 ExecuteAt 而非 Execute。
+//
+// 由于任务的实际执行时间可能因调度延迟而略晚于计划执行时间，对齐的周期性任务可以通过 scheduled 检测并修正这一延迟，
+// 例如以 scheduled 而非 time.Now() 作为统计窗口的边界。
+//
+// 关键行为说明：
+//  - scheduled 为计时器的过期时间，即 Timer.ExpiresAt 在执行时的返回值，精度为毫秒，时区为 UTC
+//  - 通过 NewLoopTask 及 NewForeverLoopTask 包装的 TimedTask 同样将接收到每次执行的计划执行时间
+//  - 通过 LoopNow 同步执行的首次迭代，scheduled 为调用 LoopNow 时截断至毫秒的当前时间
+type TimedTask interface {
+    Task
+
+    // ExecuteAt 执行任务，scheduled 为本次执行的计划执行时间
+    ExecuteAt(scheduled time.Time)
+}
+
+// TimedTaskFN 定义了一个接收计划执行时间的任务函数类型，它实现了 TimedTask 接口。
+//
+// 当通过 Execute 直接调用时，将以 time.Now() 作为计划执行时间。
+type TimedTaskFN func(scheduled time.Time)
+
+func (f TimedTaskFN) Execute() {
+    f(time.Now())
+}
+
+func (f TimedTaskFN) ExecuteAt(scheduled time.Time) {
+    f(scheduled)
+}
+
+// execute 执行任务，当任务实现了 TimedTask 时将以 scheduled 调用 ExecuteAt，
+// 当任务实现了 errorAware 时将由其把执行过程中产生的错误交由 handler 处理
+func execute(task Task, scheduled time.Time, handler func(err error)) {
+    if aware, ok := task.(errorAware); ok {
+        aware.executeWith(scheduled, handler)
+        return
+    }
+    if timed, ok := task.(TimedTask); ok {
+        timed.ExecuteAt(scheduled)
+        return
+    }
+    task.Execute()
+}
+
+// ErrTask 是一个执行后返回错误的任务，通过 NewErrTask 转换为 Task 后即可被时间轮调度。
+//
+// 相较于在 Task 的闭包中自行处理错误，ErrTask 返回的非 nil 错误将交由时间轮通过 WithErrorHandler 设置的处理函数统一处理，
+// 适用于需要集中记录日志或统计失败次数的定时任务。
+type ErrTask interface {
+    // Execute 执行任务，返回的非 nil 错误将交由时间轮的错误处理函数处理
+    Execute() error
+}
+
+// ErrTaskFN 定义了一个返回错误的任务函数类型，它实现了 ErrTask 接口。
+type ErrTaskFN func() error
+
+func (f ErrTaskFN) Execute() error {
+    return f()
+}
+
+// NewErrTask 将 ErrTask 转换为 Task，以便通过 After、Cron、NewLoopTask 等方式进行调度。
+//
+// 关键行为说明：
+//  - 任务返回的非 nil 错误将交由调度该任务的时间轮通过 WithErrorHandler 设置的处理函数处理，返回 nil 时不会调用处理函数
+//  - 错误处理函数在执行时由时间轮传入，同一个任务在多个时间轮中调度时，错误将交由各自时间轮的处理函数处理
+//  - 不经过时间轮而直接调用 Execute 时，返回的错误将被忽略
+//  - 任务执行过程中发生的 panic 不属于错误，仍然由执行器处理
+func NewErrTask(task ErrTask) Task {
+    return &errTask{task: task}
+}
+
+// StopLoop 是 LoopTask.Next 及 Schedule.Next 用于表示停止调度的哨兵值。
+//
+// 当 Next 返回 StopLoop 时，任务将被干净地停止，对应的 Timer.Stopped 将返回 true。
+// StopLoop 即为零值时间，因此可以通过 IsStop 进行判断。
+var StopLoop = time.Time{}
+
+// IsStop 判断 LoopTask.Next 或 Schedule.Next 返回的时间是否表示停止调度，即是否为 StopLoop。
+func IsStop(t time.Time) bool {
+    return t.IsZero()
+}
+
 // LoopTask 是一个循环任务，它被用来在计时器到达指定的过期时间时执行，并且可以指定下一次执行的时间
 type LoopTask interface {
     Task
 
     // Next 返回下一次执行的时间
-    //  - 参数 previous 表示了上一次的执行时间，当返回的时间小于 previous 时，任务将不再执行
+    //  - 参数 previous 表示了上一次的执行时间
+    //  - 返回 StopLoop 时任务将被停止，这是自我终止的推荐方式
+    //  - 返回的时间不晚于 previous 时，任务同样将被停止
     Next(previous time.Time) time.Time
 }
 
+// RepeatTask 是一个通过执行结果决定下一次执行延迟的任务，通过 Wheel.Repeat 进行调度。
+//
+// 相较于需要同时实现 Execute 与 Next 的 LoopTask，RepeatTask 仅需实现一个方法，适用于退避重试、动态轮询等间隔随执行结果变化的场景。
+type RepeatTask interface {
+    // Execute 执行任务，并返回下一次执行前需要等待的时长
+    //  - 当 again 为 false 时任务将被停止，此时 repeatAfter 将被忽略
+    //  - 当 repeatAfter 小于等于 0 时，将以 1 毫秒作为下一次执行的延迟
+    Execute() (repeatAfter time.Duration, again bool)
+}
+
+// RepeatTaskFN 定义了一个返回下一次执行延迟的任务函数类型，它实现了 RepeatTask 接口。
+type RepeatTaskFN func() (repeatAfter time.Duration, again bool)
+
+func (f RepeatTaskFN) Execute() (repeatAfter time.Duration, again bool) {
+    return f()
+}
+
 // NewLoopTask 创建具有生命周期管理的延迟执行任务，支持动态策略配置和同名任务替换。
 //
 // 任务调度策略通过参数组合实现灵活控制：interval 参数控制任务的循环间隔，当该值小于等于 0 时则任务将尽可能快地连续执行。
 // times 参数限制最大执行次数，非正值时任务将持续运行直至主动终止，为零时任务将不被执行。
 // task 参数指定具体要执行的任务。
 //
-// 时间参数精度取决于系统时钟，实际执行可能存在毫秒级偏差。
+// 时间参数精度取决于系统时钟，实际执行可能存在毫秒级偏差。通过 Wheel.Loop 调度时，小于时间轮刻度的 interval 将被向上取整至一个刻度。
 //
 // 关键行为说明：
 //  - 当父级上下文关闭时，已进入执行阶段的任务会完成当前操作再退出
 //  - 连续执行模式中，若任务耗时超过间隔时长，下次执行将顺延至当前操作完成
 func NewLoopTask(interval time.Duration, times int, task Task) LoopTask {
-    return &loopTask{
+    t := &loopTask{
         interval: interval,
-        times:    times,
         task:     task,
     }
+    t.times.Store(int64(times))
+    return t
 }
 
 // NewForeverLoopTask 创建一个无限循环执行的任务，基于给定的时间间隔和任务。
@@ -84,28 +188,168 @@ func NewForeverLoopTask(interval time.Duration, task Task) LoopTask {
     return NewLoopTask(interval, -1, task)
 }
 
+// ChainStep 是 Wheel.Chain 中的一个步骤，Task 将在上一个步骤执行完成后经过 Delay 执行
+type ChainStep struct {
+    Delay time.Duration // 上一个步骤执行完成后等待的时长
+    Task  Task          // 步骤执行的任务
+}
+
+// clockAware 是可以绑定时间源的任务，时间轮会在调度前将自身的 Clock 绑定到实现了该接口的任务上
+type clockAware interface {
+    bindClock(clock Clock)
+}
+
+// errorAware 是会产生错误的任务或包含此类任务的容器，时间轮会在执行时将自身的错误处理函数传入，而不会修改任务本身
+type errorAware interface {
+    // executeWith 以 scheduled 作为计划执行时间执行任务，并将产生的错误交由 handler 处理，handler 为 nil 时错误将被忽略
+    executeWith(scheduled time.Time, handler func(err error))
+}
+
+// errTask 将 ErrTask 适配为 Task，并将返回的错误交由执行时传入的错误处理函数处理
+type errTask struct {
+    task ErrTask
+}
+
+func (f *errTask) executeWith(_ time.Time, handler func(err error)) {
+    if err := f.task.Execute(); err != nil && handler != nil {
+        handler(err)
+    }
+}
+
+func (f *errTask) Execute() {
+    f.executeWith(time.Time{}, nil)
+}
+
 type loopTask struct {
     interval time.Duration
-    times    int
+    times    atomic.Int64 // 剩余的执行次数，负值表示不限次数
     task     Task
+    clock    Clock
+}
+
+func (f *loopTask) bindClock(clock Clock) {
+    f.clock = clock
+}
+
+// spec 返回循环任务的调度方式，剩余的执行次数将随执行而更新
+func (f *loopTask) spec() ScheduleSpec {
+    return ScheduleSpec{Kind: SpecKindInterval, Interval: f.interval, Remaining: int(f.times.Load())}
 }
 
 func (f *loopTask) Next(previous time.Time) time.Time {
-    if f.times == 0 {
-        return time.Time{}
+    if f.times.Load() == 0 {
+        return StopLoop
     }
-    if now := time.Now(); previous.Before(now) {
+    clock := f.clock
+    if clock == nil {
+        clock = defaultClock
+    }
+    if now := clock.Now(); previous.Before(now) {
         previous = now
     }
     return previous.Add(f.interval)
 }
 
 func (f *loopTask) Execute() {
-    if f.times == 0 {
+    f.run(f.task.Execute)
+}
+
+func (f *loopTask) ExecuteAt(scheduled time.Time) {
+    f.executeWith(scheduled, nil)
+}
+
+func (f *loopTask) executeWith(scheduled time.Time, handler func(err error)) {
+    f.run(func() {
+        execute(f.task, scheduled, handler)
+    })
+}
+
+// run 在剩余执行次数不为零时执行 task，并扣减剩余的执行次数
+func (f *loopTask) run(task func()) {
+    times := f.times.Load()
+    if times == 0 {
         return
     }
-    f.task.Execute()
-    if f.times > 0 {
-        f.times--
+    task()
+    if times > 0 {
+        f.times.Add(-1)
+    }
+}
+
+func newChainTask(first Task, steps []ChainStep) *chainTask {
+    return &chainTask{steps: append([]ChainStep{{Task: first}}, steps...)}
+}
+
+// chainTask 将一组 ChainStep 适配为 LoopTask，每次执行一个步骤，以复用 Wheel.Loop 的自我调度机制
+type chainTask struct {
+    steps []ChainStep
+    index int // 下一个待执行的步骤
+    clock Clock
+}
+
+func (f *chainTask) bindClock(clock Clock) {
+    f.clock = clock
+}
+
+func (f *chainTask) Next(previous time.Time) time.Time {
+    if f.index >= len(f.steps) {
+        return StopLoop
+    }
+    if now := f.clock.Now(); previous.Before(now) {
+        previous = now
+    }
+    return previous.Add(max(f.steps[f.index].Delay, time.Millisecond))
+}
+
+func (f *chainTask) Execute() {
+    f.run(f.steps[f.index].Task.Execute)
+}
+
+func (f *chainTask) ExecuteAt(scheduled time.Time) {
+    f.executeWith(scheduled, nil)
+}
+
+func (f *chainTask) executeWith(scheduled time.Time, handler func(err error)) {
+    task := f.steps[f.index].Task
+    f.run(func() {
+        execute(task, scheduled, handler)
+    })
+}
+
+// run 执行当前步骤，仅在执行成功完成后才推进至下一个步骤，发生 panic 时任务链将被终止
+func (f *chainTask) run(task func()) {
+    next := f.index + 1
+    f.index = len(f.steps)
+    task()
+    f.index = next
+}
+
+// repeatTask 将 RepeatTask 适配为 LoopTask，以复用 Wheel.Loop 的自我调度机制
+type repeatTask struct {
+    task  RepeatTask
+    clock Clock
+    after time.Duration // 最近一次执行返回的延迟
+    again bool          // 最近一次执行是否要求继续调度
+}
+
+func (f *repeatTask) bindClock(clock Clock) {
+    f.clock = clock
+}
+
+func (f *repeatTask) Next(previous time.Time) time.Time {
+    if !f.again {
+        return StopLoop
+    }
+    if now := f.clock.Now(); previous.Before(now) {
+        previous = now
+    }
+    return previous.Add(f.after)
+}
+
+func (f *repeatTask) Execute() {
+    f.again = false
+    f.after, f.again = f.task.Execute()
+    if f.after <= 0 {
+        f.after = time.Millisecond
     }
 }
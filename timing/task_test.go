@@ -0,0 +1,73 @@
+package timing_test
+
+import (
+    "errors"
+    "github.com/kercylan98/chrono/timing"
+    "testing"
+    "time"
+)
+
+func TestErrTask(t *testing.T) {
+    var handled []error
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithErrorHandler(func(err error) {
+            handled = append(handled, err)
+        })
+    }))
+
+    failure := errors.New("job failed")
+    tw.After(time.Second, timing.NewErrTask(timing.ErrTaskFN(func() error {
+        return failure
+    })))
+    tw.After(time.Second, timing.NewErrTask(timing.ErrTaskFN(func() error {
+        return nil
+    })))
+    if _, err := tw.Cron("@every 1s", timing.NewErrTask(timing.ErrTaskFN(func() error {
+        return failure
+    }))); err != nil {
+        t.Fatal(err)
+    }
+    tw.Loop(time.Second, timing.NewLoopTask(time.Second, 1, timing.NewErrTask(timing.ErrTaskFN(func() error {
+        return failure
+    }))))
+
+    tw.Advance(time.Second)
+    if len(handled) != 3 {
+        t.Fatalf("error handler called %d times, want 3", len(handled))
+    }
+    for _, err := range handled {
+        if !errors.Is(err, failure) {
+            t.Errorf("handled error = %v, want %v", err, failure)
+        }
+    }
+}
+
+func TestErrTask_MultipleWheels(t *testing.T) {
+    var first, second int
+    failure := errors.New("job failed")
+    task := timing.NewErrTask(timing.ErrTaskFN(func() error {
+        return failure
+    }))
+    a := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithErrorHandler(func(err error) {
+            first++
+        })
+    }))
+    b := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithErrorHandler(func(err error) {
+            second++
+        })
+    }))
+
+    // 同一个任务在多个时间轮中调度时，错误应当交由执行该任务的时间轮处理
+    a.After(time.Second, task)
+    b.After(time.Second, task)
+    a.Advance(time.Second)
+    if first != 1 || second != 0 {
+        t.Errorf("after advancing the first wheel, handled (%d, %d), want (1, 0)", first, second)
+    }
+    b.Advance(time.Second)
+    if first != 1 || second != 1 {
+        t.Errorf("after advancing the second wheel, handled (%d, %d), want (1, 1)", first, second)
+    }
+}
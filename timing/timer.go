@@ -2,17 +2,38 @@ package timing
 
 import (
 	"container/list"
+	"github.com/kercylan98/chrono"
 	"sync/atomic"
+	"time"
 )
 
 // Timer 是一个计时器，它可以在到达指定的过期时间时触发一个事件
 type Timer interface {
 	// Stop 停止计时器，如果计时器已经停止则返回 false
+	//  - 该方法是幂等且并发安全的，仅首次调用返回 true
+	//  - 已经交由执行器执行的任务不会被中断
 	Stop() bool
 
 	// Stopped 返回计时器是否已经停止
 	Stopped() bool
 
+	// Scheduled 返回计时器当前是否位于时间轮的计时桶中等待执行
+	//  - 与 Stopped 结合可以区分 "等待执行"、"已停止" 及 "已执行或正在重新调度" 三种状态
+	//  - 循环及 cron 任务在执行期间返回 false，重新调度后将再次返回 true
+	//  - 时间轮暂停期间被暂存的计时器、已经过期而直接执行的计时器均返回 false
+	Scheduled() bool
+
+	// ExpiresAt 返回计时器下一次计划执行的时间，精度为毫秒，时区为 UTC
+	//  - 对于循环及 cron 任务，该时间将在每次执行后更新，可用于预览首次执行时间
+	//  - 计时器停止后返回最后一次计划执行的时间
+	ExpiresAt() time.Time
+
+	// Spec 返回计时器的调度方式，可用于持久化并通过 Wheel.ScheduleFromSpec 重建调度
+	//  - 通过自定义的 LoopTask、Schedule 或 Repeat 创建的计时器将返回 Kind 为 SpecKindCustom 的 ScheduleSpec
+	Spec() ScheduleSpec
+
+	setSpec(spec func() ScheduleSpec)
+
 	getExpiration() int64
 
 	setExpiration(millisecond int64)
@@ -27,41 +48,64 @@ type Timer interface {
 }
 
 func newTimer(expiration int64, task func()) Timer {
-	return &timerImpl{
-		expiration: expiration,
-		task:       task,
+	t := &timerImpl{
+		task: task,
 	}
+	t.expiration.Store(expiration)
+	return t
 }
 
 type timerImpl struct {
-	expiration int64                  // 过期时间
+	expiration atomic.Int64           // 过期时间
 	task       func()                 // 任务
 	bucket     atomic.Pointer[bucket] // 所在的桶
 	element    *list.Element          // 桶元素
 	stopped    atomic.Bool            // 是否已经停止
+	spec       func() ScheduleSpec    // 调度方式，为 nil 时表示自定义调度
 }
 
 func (t *timerImpl) getExpiration() int64 {
-	return t.expiration
+	return t.expiration.Load()
 }
 
 func (t *timerImpl) setExpiration(millisecond int64) {
-	t.expiration = millisecond
+	t.expiration.Store(millisecond)
+}
+
+func (t *timerImpl) ExpiresAt() time.Time {
+	return chrono.ToTime(t.getExpiration())
+}
+
+func (t *timerImpl) Spec() ScheduleSpec {
+	if t.spec == nil {
+		return ScheduleSpec{Kind: SpecKindCustom}
+	}
+	return t.spec()
+}
+
+func (t *timerImpl) setSpec(spec func() ScheduleSpec) {
+	t.spec = spec
 }
 
 func (t *timerImpl) Stop() bool {
-	bucket := t.getBucket()
-	if bucket != nil {
-		return bucket.remove(t)
+	// 先原子地标记停止，确保并发的 flush 在重新插入时能够通过 contract 及 transfer 感知并丢弃该计时器
+	if !t.stopped.CompareAndSwap(false, true) {
+		return false
 	}
-	t.stopped.Store(true)
-	return false
+	if bucket := t.getBucket(); bucket != nil {
+		bucket.remove(t)
+	}
+	return true
 }
 
 func (t *timerImpl) Stopped() bool {
 	return t.stopped.Load()
 }
 
+func (t *timerImpl) Scheduled() bool {
+	return t.getBucket() != nil && !t.Stopped()
+}
+
 func (t *timerImpl) getTask() func() {
 	return t.task
 }
@@ -1,9 +1,10 @@
 package timing
 
 import (
-    "github.com/gorhill/cronexpr"
+    "fmt"
     "github.com/kercylan98/chrono"
     "github.com/kercylan98/chrono/timing/internal/delayqueue"
+    "sort"
     "sync"
     "time"
 )
@@ -82,11 +83,23 @@ type Wheel interface {
     // 返回 Timer 对象用于控制任务状态，如停止或检查是否已停止。
     //
     // 关键行为说明：
-    //  - 若 duration 为零或负值，任务将立即执行
+    //  - 若 duration 为零或负值，任务将立即在新的协程中交由执行器执行，After 返回时任务可能尚未执行，与 time.AfterFunc 的同步语义不同
+    //  - 设置 WithImmediateSync 后，若 duration 为零或负值，任务将在 After 返回前于调用方的协程中同步执行
     //  - 使用返回的 Timer 可以停止任务
     //  - 任务执行过程中发生 panic 将被捕获并记录，但不会中断调度
     After(duration time.Duration, task Task) Timer
 
+    // At 创建一个在指定的绝对时间执行的一次性任务。
+    //
+    // 参数 t 定义了任务的执行时刻，适用于截止时间等已知绝对时间的场景，避免在调用处计算 time.Until 带来的误差。
+    //
+    // 关键行为说明：
+    //  - 若 t 早于或等于当前时间，任务将立即执行，其执行方式与 After 一致，受 WithImmediateSync 的影响
+    //  - 执行时刻将以毫秒精度进行计算
+    //  - 设置 WithMonotonicClock 后，t 将以调用时的墙上时间换算为相对延迟，此后系统时钟的跳变不会影响执行时刻，详见 WithMonotonicClock
+    //  - 使用返回的 Timer 可以停止任务
+    At(t time.Time, task Task) Timer
+
     // Loop 创建并启动一个循环任务，根据指定的初始延迟和任务定义执行。
     //
     // duration 参数指定了首次执行前的等待时间，设置为零或负值将立即触发执行。
@@ -94,21 +107,164 @@ type Wheel interface {
     //
     // 关键行为说明：
     //  - 当 duration <= 0 时，任务将立即执行
+    //  - 当 task.Next 返回 StopLoop 时，任务将被停止，返回的 Timer.Stopped 将返回 true
+    //  - 当 task.Next 返回的时间与上一次执行时间的间隔小于 WithTick 设置的刻度时，将被向上取整至一个刻度，以避免任务在同一刻度内反复执行
     //  - 使用返回的 Timer 可以停止任务
     //  - 异常处理机制会捕获执行过程中的 panic 并记录，但不影响后续调度
     Loop(duration time.Duration, task LoopTask) Timer
 
+    // LoopNow 创建一个循环任务，并在返回前于调用方的协程中同步执行首次迭代，适用于需要预热的任务。
+    //
+    // 首次执行完成后，将以首次执行的时刻调用 task.Next 计算下一次执行时间，后续的执行与 Loop 一致由时间轮调度。
+    //
+    // 关键行为说明：
+    //  - 首次执行不经过执行器，执行过程中发生的 panic 将直接传播给调用方，而非由执行器捕获，此时任务不会被调度
+    //  - 当 task.Next 在首次执行后即返回 StopLoop 时，将返回一个已停止的 Timer
+    //  - 使用返回的 Timer 可以停止后续的执行
+    LoopNow(task LoopTask) Timer
+
+    // Repeat 创建一个首次在 initial 延迟后执行，此后由任务自身决定下一次执行延迟的循环任务。
+    //
+    // 每次执行后，task.Execute 返回的 repeatAfter 将作为下一次执行的延迟，返回的 again 为 false 时任务将被停止。
+    // 该方法基于 Loop 的自我调度机制实现，适用于退避重试等间隔动态变化的场景。
+    //
+    // 关键行为说明：
+    //  - 当 initial <= 0 时，任务将立即执行
+    //  - 下一次执行的延迟从本次执行完成时开始计算
+    //  - 任务执行过程中发生 panic 时，任务将被停止
+    //  - 使用返回的 Timer 可以停止任务
+    Repeat(initial time.Duration, task RepeatTask) Timer
+
+    // Chain 创建一个依次执行的任务链，first 将立即执行，此后每个步骤都将在上一个步骤执行完成后，经过该步骤的 Delay 再执行。
+    //
+    // 整个任务链由同一个 Timer 表示，该方法基于 Loop 的自我调度机制实现，适用于无需外部编排的轻量级顺序流程。
+    //
+    // 关键行为说明：
+    //  - 步骤的延迟从上一个步骤执行完成时开始计算，当 Delay 小于等于 0 时，将以 1 毫秒作为延迟
+    //  - 任一步骤执行过程中发生 panic 时，后续的步骤将不再执行，任务链将被停止
+    //  - 使用返回的 Timer 可以停止尚未执行的步骤，所有步骤执行完成后 Timer.Stopped 将返回 true
+    Chain(first Task, steps ...ChainStep) Timer
+
     // Cron 通过 cron 表达式创建一个周期性任务。
     //
     // 参数 cron 是一个标准的 cron 表达式，用于定义任务的执行时间。task 参数是实际执行的任务。
-    // 如果 cron 表达式无效，将返回错误。
+    // 如果 cron 表达式无效，将返回包装了 ErrInvalidCron 的错误。cron 表达式基于 WithLocation 设置的时区进行计算，
+    // 命中因夏令时切换而不存在的墙上时间时按照 WithDSTPolicy 设置的策略处理。
+    // 支持 @daily、@hourly、@every 5m 等预定义描述符，详见 CronSchedule。
     //
     // 时间参数精度取决于系统时钟，实际执行可能存在毫秒级偏差。
     Cron(cron string, task Task) (Timer, error)
 
+    // CronWindow 与 Cron 相同，但任务仅在 window 的开始时间（含）与结束时间（含）之间执行，例如仅在为期两周的活动期间每小时执行。
+    //
+    // 该方法基于 WindowSchedule 包装的 cron 调度策略实现，表达式无效时返回包装了 ErrInvalidCron 的错误。
+    //
+    // 关键行为说明：
+    //  - window 开始之前的执行时间均会被跳过，首次执行时间为 window 开始后的第一个执行时间
+    //  - 下一次执行时间晚于 window 的结束时间时，计时器将自动停止，若 window 已经结束，返回的计时器将处于停止状态
+    //  - 返回的计时器的 Spec 为 SpecKindCustom，无法通过 ScheduleFromSpec 重建
+    CronWindow(cron string, window chrono.Period, task Task) (Timer, error)
+
+    // Schedule 根据给定的调度策略创建一个周期性任务。
+    //
+    // 参数 schedule 定义了任务的执行时间，首次执行时间为 schedule.Next(now)，其中 now 为 WithClock 设置的时间源的当前时间，
+    // 此后每次执行后都将以上一次的计划执行时间与当前时间中较晚者调用 schedule.Next 计算下一次执行时间。
+    // 传入 schedule.Next 的时间均位于 WithLocation 设置的时区中。
+    //
+    // 关键行为说明：
+    //  - 当 schedule.Next 返回 StopLoop 或不晚于上一次执行时间的时间时，任务将被停止
+    //  - 暂停恢复或任务耗时超过间隔而错过的执行不会被补偿，任务将从当前时间起的下一次执行时间继续
+    //  - 与 Loop 一致，执行时间与上一次执行时间（首次执行时为当前时间）的间隔小于 WithTick 设置的刻度时，将被向上取整至一个刻度，
+    //    因此 Cron 的 "@every 100us" 等小于一个刻度的间隔将以刻度为间隔执行
+    //  - 使用返回的 Timer 可以停止任务
+    Schedule(schedule Schedule, task Task) Timer
+
+    // AtEndOf 创建一个在当前时间所处的 unit 时间单位的结束点执行的一次性任务，例如 chrono.UnitMinute 表示在本分钟的 59.999 秒执行。
+    //
+    // 结束点的计算规则与 chrono.EndOf 一致，当前时间取自 WithClock 设置的时间源，并基于 WithLocation 设置的时区进行计算。
+    //
+    // 关键行为说明：
+    //  - 执行时刻将以毫秒精度进行计算，因此结束点将被截断至所在毫秒的起始，例如 59.999999999 秒将在 59.999 秒执行
+    //  - 对于定义外的单位，该方法会抛出异常，与 chrono.EndOf 的行为一致
+    //  - 使用返回的 Timer 可以停止任务
+    AtEndOf(unit chrono.Unit, task Task) Timer
+
+    // LoopEndOf 创建一个在每个 unit 时间单位的结束点执行的周期性任务，适用于 "每分钟结束时刷新指标" 等与日历边界对齐的场景。
+    //
+    // 首次执行时刻与 AtEndOf 一致，此后每次执行完成后都将在下一个时间单位的结束点再次执行，其调度基于 Schedule 实现。
+    //
+    // 关键行为说明：
+    //  - 执行时刻将以毫秒精度进行计算，距离当前时间单位结束不足 1 毫秒时首次执行将顺延至下一个时间单位的结束点
+    //  - 月、年及星期等日历单位按照日历进行推进，不受月份天数及夏令时的影响
+    //  - 对于定义外的单位，该方法会抛出异常，与 chrono.EndOf 的行为一致
+    //  - 使用返回的 Timer 可以停止任务
+    LoopEndOf(unit chrono.Unit, task Task) Timer
+
+    // ScheduleFromSpec 根据通过 Timer.Spec 获取的 spec 重建调度，适用于在重启后恢复持久化的任务。
+    //
+    // 关键行为说明：
+    //  - SpecKindOnce 将通过 At 在 spec.At 执行，若该时间已经过去则立即执行
+    //  - SpecKindInterval 将以当前时间为起点，在 spec.Interval 后首次执行，并至多执行 spec.Remaining 次
+    //  - SpecKindCron 将以当前时间为起点重新计算下一次执行时间，表达式无效时返回包装了 ErrInvalidCron 的错误
+    //  - 其余 Kind 将返回包装了 ErrUnsupportedSpec 的错误
+    ScheduleFromSpec(spec ScheduleSpec, task Task) (Timer, error)
+
     // Named 获取使用命名维护任务的时间轮 API
     //   - 当 topic 不为空时，将返回一个命名空间为 topic 的 Named 实例，不同的 Named 实例之间的任务不会相互影响
     Named(topic ...string) Named
+
+    // Upcoming 返回在 within 时长内即将到期的计时器快照，结果按照过期时间升序排列。
+    //
+    // 该方法以只读的方式遍历时间轮及其溢出轮中的所有桶，不会移除或执行任何计时器，适用于仪表盘展示及调试等场景。
+    //
+    // 关键行为说明：
+    //  - 返回的快照可能在返回后立即过时，其中的计时器可能已被执行或停止
+    //  - 已到期但尚未从桶中转移的计时器同样会被包含在内
+    Upcoming(within time.Duration) []Timer
+
+    // Stats 返回时间轮当前运行状态的快照，包括挂载的计时器数量及延迟队列中等待到期的桶数量。
+    Stats() Stats
+
+    // SetExecutor 在运行时原子地替换时间轮的执行器，已挂载的计时器不受影响，此后到期的任务都将交由新的执行器执行。
+    //
+    // 适用于在不重建时间轮、不丢失已调度任务的情况下切换执行策略，例如从同步执行切换为有界队列。
+    //
+    // 关键行为说明：
+    //  - 已经交由旧执行器执行的任务将正常完成，旧执行器的生命周期需由调用方自行管理
+    //  - 当 executor 为 nil 时将使用默认的同步执行器
+    //  - 溢出轮同样将使用新的执行器
+    SetExecutor(executor Executor)
+
+    // BucketSizes 返回时间轮中每个桶当前挂载的计时器数量，索引即为桶所对应的刻度，适用于调整 WithTick 及 WithSize 时观察计时器的分布情况。
+    //
+    // 当大部分计时器集中在少数几个桶中时，通常意味着时间轮的刻度或大小设置不当。
+    //
+    // 关键行为说明：
+    //  - 快照在不持有全局锁的情况下逐个读取各个桶，因此结果是近似的，各个桶之间不保证严格一致
+    //  - 不包含溢出轮中的计时器，溢出轮的分布情况请使用 OverflowBucketSizes
+    BucketSizes() []int
+
+    // OverflowBucketSizes 返回各层溢出轮中每个桶当前挂载的计时器数量，第一维的索引 0 为第一层溢出轮，尚未创建的溢出轮不会被包含在内。
+    //
+    // 与 BucketSizes 一致，快照在不持有全局锁的情况下读取，结果是近似的。
+    OverflowBucketSizes() [][]int
+
+    // Pause 暂停时间轮中所有任务的执行，适用于维护窗口等需要冻结任务但不希望停止任务的场景。
+    //
+    // 暂停期间所有计时器均被保留，仍然可以添加或停止任务，期间到期的计时器将被暂存而不会执行。
+    //
+    // 关键行为说明：
+    //  - 重复调用 Pause 不会产生额外影响
+    //  - 已经交由执行器执行的任务不受影响
+    Pause()
+
+    // Resume 恢复时间轮中任务的执行，暂停期间到期的计时器将在调用方的协程中按照过期时间顺序各执行一次。
+    //
+    // 关键行为说明：
+    //  - 暂停期间被停止的计时器不会执行
+    //  - 循环任务及 Cron、Schedule 等周期性任务在恢复时仅执行一次，随后以恢复时的时间计算下一次执行时间，不会补偿暂停期间错过的执行次数
+    //  - 未暂停时调用 Resume 不会产生任何影响
+    Resume()
 }
 
 // wheel 是 Wheel 的默认实现
@@ -119,47 +275,231 @@ type wheel struct {
 }
 
 func (t *wheel) After(duration time.Duration, task Task) Timer {
-    timer := newTimer(chrono.ToMillisecond(time.Now().Add(duration)), task.Execute)
+    return t.at(t.getConfig().FetchClock().Now().Add(duration), task)
+}
+
+func (t *wheel) At(at time.Time, task Task) Timer {
+    if clock, ok := t.getConfig().FetchClock().(wallConverter); ok {
+        at = clock.fromWall(at)
+    }
+    return t.at(at, task)
+}
+
+// at 创建一个在 at 执行的一次性计时器，at 需位于时间轮所使用的时间源的时间基准中
+func (t *wheel) at(at time.Time, task Task) Timer {
+    var timer Timer
+    timer = newTimer(chrono.ToMillisecond(at), func() {
+        t.execute(task, timer.ExpiresAt())
+    })
+    timer.setSpec(func() ScheduleSpec {
+        return ScheduleSpec{Kind: SpecKindOnce, At: timer.ExpiresAt()}
+    })
+    config := t.getConfig()
+    if config.FetchImmediateSync() && !at.After(config.FetchClock().Now()) && !t.isPaused() {
+        // 不经过可能异步的执行器，但与默认执行器一样捕获并记录 panic，避免其传播给调用方
+        defaultExecutor.Execute(attribute(config, timer.getTask()))
+        return timer
+    }
     t.contract(timer)
     return timer
 }
 
 func (t *wheel) Loop(duration time.Duration, task LoopTask) Timer {
+    clock := t.bindClock(task)
+    return t.loop(clock.Now().Add(duration), task)
+}
+
+func (t *wheel) LoopNow(task LoopTask) Timer {
+    clock := t.bindClock(task)
+    previous := chrono.ToTime(chrono.ToMillisecond(clock.Now()))
+    t.execute(task, previous)
+
+    next := task.Next(previous)
+    if IsStop(next) || !next.After(previous) {
+        timer := newTimer(chrono.ToMillisecond(previous), func() {})
+        timer.Stop()
+        return timer
+    }
+    return t.loop(t.atLeastTick(previous, next), task)
+}
+
+// bindClock 将时间轮的时间源绑定到实现了 clockAware 的任务上，并返回该时间源
+func (t *wheel) bindClock(task LoopTask) Clock {
+    clock := t.getConfig().FetchClock()
+    if aware, ok := task.(clockAware); ok {
+        aware.bindClock(clock)
+    }
+    return clock
+}
+
+// execute 执行任务，并将执行过程中产生的错误交由时间轮的错误处理函数处理
+func (t *wheel) execute(task Task, scheduled time.Time) {
+    execute(task, scheduled, t.getConfig().FetchErrorHandler())
+}
+
+// atLeastTick 确保 next 与 previous 之间至少间隔一个刻度，小于一个刻度的间隔无法被时间轮区分，将导致任务在同一刻度内反复执行
+func (t *wheel) atLeastTick(previous, next time.Time) time.Time {
+    if tick := previous.Add(time.Duration(t.getConfig().FetchTick()) * Millisecond); next.Before(tick) {
+        return tick
+    }
+    return next
+}
+
+// loop 创建一个首次在 first 执行，此后根据 task.Next 自我调度的循环计时器
+func (t *wheel) loop(first time.Time, task LoopTask) Timer {
     var timer Timer
-    timer = newTimer(chrono.ToMillisecond(time.Now().Add(duration)), func() {
+    timer = newTimer(chrono.ToMillisecond(first), func() {
         defer func() {
             previous := chrono.ToTime(timer.getExpiration())
             next := task.Next(previous)
-            if !next.IsZero() && next.After(previous) {
-                timer.setExpiration(chrono.ToMillisecond(next))
-                t.contract(timer)
+            if IsStop(next) || !next.After(previous) {
+                timer.Stop()
+                return
             }
+            timer.setExpiration(chrono.ToMillisecond(t.atLeastTick(previous, next)))
+            t.contract(timer)
         }()
 
-        task.Execute()
+        t.execute(task, timer.ExpiresAt())
     })
+    if loop, ok := task.(*loopTask); ok {
+        timer.setSpec(loop.spec)
+    }
     t.contract(timer)
     return timer
 }
 
+func (t *wheel) Repeat(initial time.Duration, task RepeatTask) Timer {
+    return t.Loop(initial, &repeatTask{task: task})
+}
+
+func (t *wheel) Chain(first Task, steps ...ChainStep) Timer {
+    return t.Loop(0, newChainTask(first, steps))
+}
+
 func (t *wheel) Cron(cron string, task Task) (Timer, error) {
-    expression, err := cronexpr.Parse(cron)
+    schedule, err := CronSchedule(cron, t.getConfig().FetchDSTPolicy())
+    if err != nil {
+        return nil, err
+    }
+    timer := t.Schedule(schedule, task)
+    timer.setSpec(func() ScheduleSpec {
+        return ScheduleSpec{Kind: SpecKindCron, Cron: cron}
+    })
+    return timer, nil
+}
+
+func (t *wheel) CronWindow(cron string, window chrono.Period, task Task) (Timer, error) {
+    schedule, err := CronSchedule(cron, t.getConfig().FetchDSTPolicy())
     if err != nil {
         return nil, err
     }
-    var now = time.Now()
+    return t.Schedule(WindowSchedule(schedule, window), task), nil
+}
+
+func (t *wheel) ScheduleFromSpec(spec ScheduleSpec, task Task) (Timer, error) {
+    switch spec.Kind {
+    case SpecKindOnce:
+        return t.At(spec.At, task), nil
+    case SpecKindInterval:
+        return t.Loop(spec.Interval, NewLoopTask(spec.Interval, spec.Remaining, task)), nil
+    case SpecKindCron:
+        return t.Cron(spec.Cron, task)
+    default:
+        return nil, fmt.Errorf("timing: %w: kind %d", ErrUnsupportedSpec, spec.Kind)
+    }
+}
+
+func (t *wheel) Schedule(schedule Schedule, task Task) Timer {
     var timer Timer
-    timer = newTimer(chrono.ToMillisecond(expression.Next(now)), func() {
+    location := t.getConfig().FetchLocation()
+    now := t.getConfig().FetchClock().Now().In(location)
+    first := schedule.Next(now)
+    timer = newTimer(chrono.ToMillisecond(first), func() {
         defer func() {
-            next := expression.Next(now)
-            timer.setExpiration(chrono.ToMillisecond(next))
+            // 与 loopTask.Next 一致，以计划执行时间与当前时间中较晚者为基准，避免暂停恢复或任务耗时过长后连续补偿错过的执行
+            previous := chrono.ToTime(timer.getExpiration()).In(location)
+            previous = chrono.Max(previous, t.getConfig().FetchClock().Now().In(location))
+            next := schedule.Next(previous)
+            if IsStop(next) || !next.After(previous) {
+                timer.Stop()
+                return
+            }
+            timer.setExpiration(chrono.ToMillisecond(t.atLeastTick(previous, next)))
             t.contract(timer)
         }()
 
-        task.Execute()
+        t.execute(task, timer.ExpiresAt())
     })
+    if IsStop(first) || !first.After(now) {
+        timer.Stop()
+        return timer
+    }
+    timer.setExpiration(chrono.ToMillisecond(t.atLeastTick(now, first)))
     t.contract(timer)
-    return timer, nil
+    return timer
+}
+
+func (t *wheel) AtEndOf(unit chrono.Unit, task Task) Timer {
+    config := t.getConfig()
+    return t.at(chrono.EndOf(config.FetchClock().Now().In(config.FetchLocation()), unit), task)
+}
+
+func (t *wheel) LoopEndOf(unit chrono.Unit, task Task) Timer {
+    // 提前校验单位，避免在调度过程中抛出异常
+    chrono.EndOf(time.Time{}, unit)
+    return t.Schedule(ScheduleFN(func(after time.Time) time.Time {
+        // 结束点以毫秒精度执行，跳过一毫秒以避免在同一个结束点重复执行
+        return chrono.EndOf(after.Add(Millisecond), unit)
+    }), task)
+}
+
+func (t *wheel) Upcoming(within time.Duration) []Timer {
+    timers := t.upcoming(chrono.ToMillisecond(t.getConfig().FetchClock().Now().Add(within)), nil)
+    sort.SliceStable(timers, func(i, j int) bool {
+        return timers[i].getExpiration() < timers[j].getExpiration()
+    })
+    return timers
+}
+
+func (t *wheel) Stats() Stats {
+    stats := Stats{
+        Name:           t.getConfig().FetchName(),
+        Timers:         t.timerCount(),
+        PendingBuckets: t.pendingBuckets(),
+    }
+    if executor, ok := t.getConfig().FetchExecutor().(interface{ Dropped() uint64 }); ok {
+        stats.DroppedTasks = executor.Dropped()
+    }
+    return stats
+}
+
+func (t *wheel) SetExecutor(executor Executor) {
+    t.setExecutor(executor)
+}
+
+func (t *wheel) BucketSizes() []int {
+    levels := t.bucketSizes(nil)
+    if len(levels) == 0 {
+        return nil
+    }
+    return levels[0]
+}
+
+func (t *wheel) OverflowBucketSizes() [][]int {
+    levels := t.bucketSizes(nil)
+    if len(levels) <= 1 {
+        return nil
+    }
+    return levels[1:]
+}
+
+func (t *wheel) Pause() {
+    t.pause()
+}
+
+func (t *wheel) Resume() {
+    t.resume()
 }
 
 func (t *wheel) Named(topic ...string) Named {
@@ -3,6 +3,7 @@ package timing
 import (
     "github.com/kercylan98/chrono"
     "github.com/kercylan98/chrono/timing/internal/delayqueue"
+    "sort"
     "sync"
     "sync/atomic"
     "time"
@@ -12,6 +13,13 @@ var (
     _ Wheel = (*wheel)(nil)
 )
 
+// elapsed 是一个已关闭的通道，延迟队列在休眠结束后通过它表示等待已经完成
+var elapsed = func() <-chan time.Time {
+    c := make(chan time.Time)
+    close(c)
+    return c
+}()
+
 func newWheelInternal(tw Wheel, config OptionsFetcher) wheelInternal {
     return &wheelInternalImpl{
         Wheel:  tw,
@@ -35,8 +43,35 @@ type wheelInternal interface {
     // contract 履行任务
     contract(timer Timer)
 
+    // transfer 转移到期桶中的计时器，已过期的计时器将在当前协程中直接交由执行器执行
+    transfer(timer Timer)
+
     // refreshDelayQueue 刷新延迟队列，避免长时间无效挂起
     refreshDelayQueue()
+
+    // timerCount 返回时间轮（含溢出轮）中挂载的计时器数量
+    timerCount() int
+
+    // pendingBuckets 返回延迟队列中等待到期的桶数量
+    pendingBuckets() int
+
+    // upcoming 将时间轮（含溢出轮）中过期时间不晚于 deadline 的计时器追加到 dst 中并返回
+    upcoming(deadline int64, dst []Timer) []Timer
+
+    // setExecutor 原子地替换时间轮（含溢出轮）的执行器
+    setExecutor(executor Executor)
+
+    // bucketSizes 将时间轮及其各层溢出轮中每个桶的计时器数量依次追加到 levels 中并返回
+    bucketSizes(levels [][]int) [][]int
+
+    // pause 暂停任务的执行，暂停期间到期的计时器将被暂存
+    pause()
+
+    // resume 恢复任务的执行，并按照过期时间顺序执行暂停期间暂存的计时器
+    resume()
+
+    // isPaused 返回时间轮是否已暂停
+    isPaused() bool
 }
 
 type wheelInternalImpl struct {
@@ -48,11 +83,15 @@ type wheelInternalImpl struct {
     queue        *delayqueue.DelayQueue[bucket] // 延迟队列
     current      int64                          // 毫秒级当前时间
     interval     int64                          // 时间轮的间隔时间
+    pauseLock    sync.Mutex                     // 暂停状态锁
+    paused       bool                           // 是否已暂停
+    held         []Timer                        // 暂停期间到期的计时器
 }
 
 func (t *wheelInternalImpl) init(startMs int64, queue *delayqueue.DelayQueue[bucket]) {
+    clock := t.getConfig().FetchClock()
     if startMs == 0 {
-        startMs = chrono.ToMillisecond(time.Now())
+        startMs = chrono.ToMillisecond(clock.Now())
     }
     tick := t.getConfig().FetchTick()
     size := t.getConfig().FetchSize()
@@ -62,20 +101,63 @@ func (t *wheelInternalImpl) init(startMs int64, queue *delayqueue.DelayQueue[buc
     t.buckets = make([]bucket, size)
 
     if queue == nil {
-        queue = delayqueue.New(int(size), func() int64 {
-            return chrono.ToMillisecond(time.Now())
+        t.getConfig().buildExecutor()
+        spin := t.getConfig().FetchSpinThreshold()
+        queue = delayqueue.New(t.getConfig().FetchQueueCapacity(), func() int64 {
+            return chrono.ToMillisecond(clock.Now())
+        }, func(delta int64) (<-chan time.Time, func()) {
+            d := time.Duration(delta) * time.Millisecond
+            if d <= spin {
+                // 即将到期时直接休眠，避免为每次短暂的等待创建新的定时器通道
+                time.Sleep(d)
+                return elapsed, nil
+            }
+            if clock, ok := clock.(cancelableClock); ok {
+                return clock.afterCancel(d)
+            }
+            return clock.After(d), nil
         }, func(bucket bucket) {
             t.advanceClock(bucket.getExpiration())
-            bucket.flush(t.contract)
-        })
+            bucket.flush(t.transfer)
+        }, t.getConfig().FetchPanicHandler())
+        if trace := t.getConfig().FetchQueueTrace(); trace != nil {
+            queue.Trace(func(event delayqueue.Event, now, delta int64) {
+                // QueueEventKind 与 delayqueue.Event 的取值一一对应
+                trace(QueueEvent{
+                    Kind:  QueueEventKind(event),
+                    Time:  chrono.ToTime(now),
+                    Delay: time.Duration(delta) * time.Millisecond,
+                })
+            })
+        }
     }
     t.queue = queue
 
     for i := range t.buckets {
-        t.buckets[i] = newBucket(t)
+        t.buckets[i] = newBucket(t, t.getConfig().FetchBucketStorage())
+    }
+
+    if t.getConfig().fetchLevel() < t.getConfig().FetchOverflowLevels() {
+        t.overflow = t.newOverflow(t.current)
     }
 }
 
+// newOverflow 创建以 current 为起始时间的溢出轮，溢出轮继承当前时间轮的配置，其刻度为当前时间轮的间隔
+func (t *wheelInternalImpl) newOverflow(current int64) Wheel {
+    config := NewConfig().
+        withTick(t.interval).
+        WithSize(int(t.getConfig().FetchSize())).
+        WithExecutor(t.getConfig().FetchExecutor()).
+        WithLocation(t.getConfig().FetchLocation()).
+        WithClock(t.getConfig().FetchClock()).
+        WithDSTPolicy(t.getConfig().FetchDSTPolicy()).
+        WithBucketStorage(t.getConfig().FetchBucketStorage()).
+        WithOverflowLevels(t.getConfig().FetchOverflowLevels()).
+        WithName(t.getConfig().FetchName()).
+        withLevel(t.getConfig().fetchLevel() + 1)
+    return GetBuilder().build(current, t.queue, config)
+}
+
 func (t *wheelInternalImpl) getConfig() OptionsFetcher {
     return t.config
 }
@@ -84,9 +166,58 @@ func (t *wheelInternalImpl) contract(timer Timer) {
     if timer.Stopped() {
         return
     }
-    if !t.add(timer) {
+    if !t.add(timer) && !t.hold(timer) {
         // 计时器已经过期，直接执行
-        go t.getConfig().FetchExecutor().Execute(timer.getTask())
+        go t.getConfig().FetchExecutor().Execute(attribute(t.getConfig(), timer.getTask()))
+    }
+}
+
+func (t *wheelInternalImpl) transfer(timer Timer) {
+    if timer.Stopped() {
+        return
+    }
+    if !t.add(timer) && !t.hold(timer) {
+        // 计时器已经过期，在当前协程中执行以保证同一桶内计时器的执行顺序
+        t.getConfig().FetchExecutor().Execute(attribute(t.getConfig(), timer.getTask()))
+    }
+}
+
+// hold 在时间轮暂停时暂存已经到期的计时器，返回是否已暂存
+func (t *wheelInternalImpl) hold(timer Timer) bool {
+    t.pauseLock.Lock()
+    defer t.pauseLock.Unlock()
+    if t.paused {
+        t.held = append(t.held, timer)
+    }
+    return t.paused
+}
+
+func (t *wheelInternalImpl) isPaused() bool {
+    t.pauseLock.Lock()
+    defer t.pauseLock.Unlock()
+    return t.paused
+}
+
+func (t *wheelInternalImpl) pause() {
+    t.pauseLock.Lock()
+    defer t.pauseLock.Unlock()
+    t.paused = true
+}
+
+func (t *wheelInternalImpl) resume() {
+    t.pauseLock.Lock()
+    held := t.held
+    t.paused, t.held = false, nil
+    t.pauseLock.Unlock()
+
+    // 暂存的计时器可能来自不同桶的并发转移，需要按照过期时间重新排序，相同过期时间的计时器保持暂存顺序
+    sort.SliceStable(held, func(i, j int) bool {
+        return held[i].getExpiration() < held[j].getExpiration()
+    })
+    for _, timer := range held {
+        if !timer.Stopped() {
+            t.getConfig().FetchExecutor().Execute(attribute(t.getConfig(), timer.getTask()))
+        }
     }
 }
 
@@ -112,11 +243,7 @@ func (t *wheelInternalImpl) add(timer Timer) bool {
         t.overflowLock.Lock()
         defer t.overflowLock.Unlock()
         if t.overflow == nil {
-            config := NewConfig().
-                withTick(t.interval).
-                WithSize(int(t.getConfig().FetchSize())).
-                WithExecutor(t.getConfig().FetchExecutor())
-            t.overflow = GetBuilder().build(current, t.queue, config)
+            t.overflow = t.newOverflow(current)
         }
         return t.overflow.add(timer)
     }
@@ -142,3 +269,59 @@ func (t *wheelInternalImpl) advanceClock(expiration int64) {
 func (t *wheelInternalImpl) refreshDelayQueue() {
     t.queue.Refresh()
 }
+
+func (t *wheelInternalImpl) timerCount() int {
+    var count int
+    for _, b := range t.buckets {
+        count += b.Size()
+    }
+
+    t.overflowLock.RLock()
+    defer t.overflowLock.RUnlock()
+    if t.overflow != nil {
+        count += t.overflow.timerCount()
+    }
+    return count
+}
+
+func (t *wheelInternalImpl) pendingBuckets() int {
+    return t.queue.Len()
+}
+
+func (t *wheelInternalImpl) setExecutor(executor Executor) {
+    t.getConfig().setExecutor(executor)
+
+    t.overflowLock.RLock()
+    defer t.overflowLock.RUnlock()
+    if t.overflow != nil {
+        t.overflow.setExecutor(executor)
+    }
+}
+
+func (t *wheelInternalImpl) bucketSizes(levels [][]int) [][]int {
+    sizes := make([]int, len(t.buckets))
+    for i, b := range t.buckets {
+        sizes[i] = b.Size()
+    }
+    levels = append(levels, sizes)
+
+    t.overflowLock.RLock()
+    defer t.overflowLock.RUnlock()
+    if t.overflow != nil {
+        levels = t.overflow.bucketSizes(levels)
+    }
+    return levels
+}
+
+func (t *wheelInternalImpl) upcoming(deadline int64, dst []Timer) []Timer {
+    for _, b := range t.buckets {
+        dst = b.collect(deadline, dst)
+    }
+
+    t.overflowLock.RLock()
+    defer t.overflowLock.RUnlock()
+    if t.overflow != nil {
+        dst = t.overflow.upcoming(deadline, dst)
+    }
+    return dst
+}
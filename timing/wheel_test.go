@@ -1,7 +1,14 @@
 package timing_test
 
 import (
+    "errors"
     "fmt"
+    "reflect"
+    "slices"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "github.com/kercylan98/chrono"
     "github.com/kercylan98/chrono/timing"
     "testing"
     "time"
@@ -15,3 +22,826 @@ func TestWheel_After(t *testing.T) {
 
     time.Sleep(time.Second)
 }
+
+func TestWheel_Stats(t *testing.T) {
+    tw := timing.New()
+    tw.After(time.Minute, timing.TaskFN(func() {}))
+    tw.After(time.Hour, timing.TaskFN(func() {}))
+
+    stats := tw.Stats()
+    if stats.Timers != 2 {
+        t.Errorf("Stats().Timers = %d, want 2", stats.Timers)
+    }
+    if stats.PendingBuckets != 2 {
+        t.Errorf("Stats().PendingBuckets = %d, want 2", stats.PendingBuckets)
+    }
+}
+
+func TestWheel_BucketSizes(t *testing.T) {
+    clock := timing.NewManualClock(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC))
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(clock).WithTick(time.Second).WithSize(10)
+    }))
+    for i := 0; i < 3; i++ {
+        tw.After(5*time.Second, timing.TaskFN(func() {}))
+    }
+    tw.After(7*time.Second, timing.TaskFN(func() {}))
+    tw.After(time.Minute, timing.TaskFN(func() {}))
+
+    sum := func(sizes []int) (total, peak int) {
+        for _, size := range sizes {
+            total += size
+            peak = max(peak, size)
+        }
+        return
+    }
+
+    sizes := tw.BucketSizes()
+    if len(sizes) != 10 {
+        t.Fatalf("len(BucketSizes()) = %d, want 10", len(sizes))
+    }
+    if total, peak := sum(sizes); total != 4 || peak != 3 || sizes[5] != 3 || sizes[7] != 1 {
+        t.Errorf("BucketSizes() = %v, want 3 timers in slot 5 and 1 in slot 7", sizes)
+    }
+
+    overflow := tw.OverflowBucketSizes()
+    if len(overflow) != 1 {
+        t.Fatalf("len(OverflowBucketSizes()) = %d, want 1", len(overflow))
+    }
+    if total, _ := sum(overflow[0]); total != 1 {
+        t.Errorf("OverflowBucketSizes() = %v, want 1 timer", overflow)
+    }
+}
+
+func TestWheel_TimedTask(t *testing.T) {
+    // 执行器在执行任务前延迟 20ms，模拟调度延迟
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithExecutor(timing.ExecutorFN(func(task func()) {
+            time.Sleep(20 * time.Millisecond)
+            task()
+        }))
+    }))
+
+    type execution struct {
+        scheduled time.Time
+        now       time.Time
+    }
+    done := make(chan execution, 1)
+    timer := tw.After(50*time.Millisecond, timing.TimedTaskFN(func(scheduled time.Time) {
+        done <- execution{scheduled, time.Now()}
+    }))
+
+    select {
+    case e := <-done:
+        if !e.scheduled.Equal(timer.ExpiresAt()) {
+            t.Errorf("ExecuteAt() received %v, want the scheduled instant %v", e.scheduled, timer.ExpiresAt())
+        }
+        if lag := e.now.Sub(e.scheduled); lag < 20*time.Millisecond {
+            t.Errorf("task ran %v after the scheduled instant, want at least the 20ms executor delay", lag)
+        }
+    case <-time.After(time.Second):
+        t.Fatalf("timed task did not fire")
+    }
+
+    // 循环任务包装的 TimedTask 同样接收每次执行的计划执行时间
+    start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+    mock := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start))
+    }))
+    var scheduled []time.Time
+    mock.Loop(time.Second, timing.NewLoopTask(time.Second, 3, timing.TimedTaskFN(func(at time.Time) {
+        scheduled = append(scheduled, at)
+    })))
+    mock.Advance(5 * time.Second)
+    if want := []time.Time{start.Add(time.Second), start.Add(2 * time.Second), start.Add(3 * time.Second)}; !reflect.DeepEqual(scheduled, want) {
+        t.Errorf("loop ExecuteAt() received %v, want %v", scheduled, want)
+    }
+}
+
+func TestWheel_SpinThreshold(t *testing.T) {
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithSpinThreshold(2 * time.Millisecond)
+    }))
+
+    const timers = 100
+    var wg sync.WaitGroup
+    wg.Add(timers)
+    start := time.Now()
+    for i := 0; i < timers; i++ {
+        tw.After(time.Duration(i%3)*time.Millisecond+time.Millisecond, timing.TaskFN(wg.Done))
+    }
+    // 休眠期间加入的更晚到期的计时器同样需要被正常处理
+    done := make(chan struct{})
+    tw.After(50*time.Millisecond, timing.TaskFN(func() {
+        close(done)
+    }))
+
+    select {
+    case <-done:
+        wg.Wait()
+        if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+            t.Errorf("timer fired after %v, want about 50ms", elapsed)
+        }
+    case <-time.After(time.Second):
+        t.Fatalf("timers did not fire with a spin threshold")
+    }
+}
+
+func BenchmarkSpinThreshold(b *testing.B) {
+    for _, bm := range []struct {
+        name      string
+        threshold time.Duration
+    }{
+        {"Disabled", 0},
+        {"2ms", 2 * time.Millisecond},
+    } {
+        b.Run(bm.name, func(b *testing.B) {
+            tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+                config.WithSpinThreshold(bm.threshold)
+            }))
+            done := make(chan struct{}, 1)
+            task := timing.TaskFN(func() {
+                done <- struct{}{}
+            })
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                tw.After(time.Duration(i%2)*time.Millisecond+time.Millisecond, task)
+                <-done
+            }
+        })
+    }
+}
+
+func TestTimer_Scheduled(t *testing.T) {
+    tw := timing.New()
+
+    fired := make(chan struct{})
+    timer := tw.After(50*time.Millisecond, timing.TaskFN(func() {
+        close(fired)
+    }))
+    if !timer.Scheduled() || timer.Stopped() {
+        t.Fatalf("new timer Scheduled() = %v, Stopped() = %v, want true and false", timer.Scheduled(), timer.Stopped())
+    }
+
+    select {
+    case <-fired:
+    case <-time.After(time.Second):
+        t.Fatalf("timer did not fire")
+    }
+    if timer.Scheduled() || timer.Stopped() {
+        t.Errorf("fired timer Scheduled() = %v, Stopped() = %v, want false and false", timer.Scheduled(), timer.Stopped())
+    }
+
+    stopped := tw.After(time.Minute, timing.TaskFN(func() {}))
+    stopped.Stop()
+    if stopped.Scheduled() || !stopped.Stopped() {
+        t.Errorf("stopped timer Scheduled() = %v, Stopped() = %v, want false and true", stopped.Scheduled(), stopped.Stopped())
+    }
+
+    // 循环任务在执行期间不位于计时桶中，执行完成后将被重新调度
+    executing := make(chan bool, 1)
+    var loop timing.Timer
+    var mu sync.Mutex
+    mu.Lock()
+    loop = tw.Loop(20*time.Millisecond, timing.NewLoopTask(time.Minute, 2, timing.TaskFN(func() {
+        mu.Lock()
+        defer mu.Unlock()
+        executing <- loop.Scheduled()
+    })))
+    mu.Unlock()
+    select {
+    case scheduled := <-executing:
+        if scheduled {
+            t.Errorf("executing loop timer Scheduled() = true, want false")
+        }
+    case <-time.After(time.Second):
+        t.Fatalf("loop timer did not fire")
+    }
+    deadline := time.Now().Add(time.Second)
+    for !loop.Scheduled() && time.Now().Before(deadline) {
+        time.Sleep(time.Millisecond)
+    }
+    if !loop.Scheduled() {
+        t.Errorf("rescheduled loop timer Scheduled() = false, want true")
+    }
+    loop.Stop()
+}
+
+func TestWheel_SetExecutor(t *testing.T) {
+    var previous, current atomic.Int32
+    executor := func(counter *atomic.Int32) timing.Executor {
+        return timing.ExecutorFN(func(task func()) {
+            counter.Add(1)
+            task()
+        })
+    }
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithExecutor(executor(&previous))
+    }))
+
+    task := timing.TaskFN(func() {})
+    tw.After(time.Second, task)
+    tw.After(2*time.Second, task)
+    tw.Advance(time.Second)
+
+    tw.SetExecutor(executor(&current))
+    tw.After(time.Second, task)
+    tw.Advance(time.Second)
+
+    if previous.Load() != 1 || current.Load() != 2 {
+        t.Errorf("previous executor ran %d tasks, current ran %d, want 1 and 2", previous.Load(), current.Load())
+    }
+
+    // 溢出轮中的计时器同样交由新的执行器执行
+    var swapped atomic.Int32
+    done := make(chan struct{})
+    wheel := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithExecutor(executor(&previous))
+    }))
+    wheel.After(100*time.Millisecond, timing.TaskFN(func() {
+        close(done)
+    }))
+    wheel.SetExecutor(executor(&swapped))
+    select {
+    case <-done:
+    case <-time.After(time.Second):
+        t.Fatalf("task did not fire after swapping the executor")
+    }
+    if swapped.Load() != 1 {
+        t.Errorf("swapped executor ran %d tasks, want 1", swapped.Load())
+    }
+}
+
+func TestWheel_SameExpirationFIFO(t *testing.T) {
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithTick(100 * time.Millisecond)
+        config.WithExecutor(timing.ExecutorFN(func(task func()) {
+            task()
+        }))
+    }))
+
+    // 对齐到刻度的前半段，确保所有任务落入同一个桶中
+    for time.Now().UnixMilli()%100 >= 50 {
+        time.Sleep(time.Millisecond)
+    }
+
+    const n = 100
+    var mu sync.Mutex
+    var order []int
+    var wg sync.WaitGroup
+    wg.Add(n)
+    for i := 0; i < n; i++ {
+        tw.After(200*time.Millisecond, timing.TaskFN(func() {
+            mu.Lock()
+            order = append(order, i)
+            mu.Unlock()
+            wg.Done()
+        }))
+    }
+    wg.Wait()
+
+    for i, v := range order {
+        if v != i {
+            t.Fatalf("order[%d] = %d, want %d", i, v, i)
+        }
+    }
+}
+
+func TestWheel_Upcoming(t *testing.T) {
+    tw := timing.New()
+    late := tw.After(time.Hour, timing.TaskFN(func() {}))
+    soon := tw.After(time.Minute, timing.TaskFN(func() {}))
+    tw.After(2*time.Hour, timing.TaskFN(func() {}))
+
+    upcoming := tw.Upcoming(90 * time.Minute)
+    if len(upcoming) != 2 || upcoming[0] != soon || upcoming[1] != late {
+        t.Errorf("Upcoming() = %v, want [%v %v]", upcoming, soon, late)
+    }
+    if stats := tw.Stats(); stats.Timers != 3 {
+        t.Errorf("Stats().Timers = %d, want 3", stats.Timers)
+    }
+}
+
+func TestWheel_At(t *testing.T) {
+    tw := timing.New()
+    done := make(chan struct{})
+    tw.At(time.Now().Add(-time.Hour), timing.TaskFN(func() {
+        close(done)
+    }))
+
+    select {
+    case <-done:
+    case <-time.After(100 * time.Millisecond):
+        t.Errorf("task scheduled in the past was not executed immediately")
+    }
+}
+
+func TestWheel_ImmediateSync(t *testing.T) {
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithImmediateSync()
+    }))
+
+    for _, delay := range []time.Duration{0, -time.Second} {
+        var done bool
+        tw.After(delay, timing.TaskFN(func() {
+            done = true
+        }))
+        if !done {
+            t.Errorf("After(%v) returned before the task completed", delay)
+        }
+    }
+
+    var done atomic.Bool
+    timer := tw.After(50*time.Millisecond, timing.TaskFN(func() {
+        done.Store(true)
+    }))
+    if done.Load() {
+        t.Errorf("After(50ms) executed the task synchronously, want asynchronous execution")
+    }
+    timer.Stop()
+}
+
+func TestWheel_ImmediateSyncPanic(t *testing.T) {
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithImmediateSync().WithName("sync")
+    }))
+
+    defer func() {
+        if err := recover(); err != nil {
+            t.Errorf("After(0) propagated the task panic to the caller: %v", err)
+        }
+    }()
+    var done bool
+    tw.After(0, timing.TaskFN(func() {
+        panic("boom")
+    }))
+    tw.After(0, timing.TaskFN(func() {
+        done = true
+    }))
+    if !done {
+        t.Errorf("After(0) did not execute the task following a panicking one")
+    }
+}
+
+func TestWheel_LoopStop(t *testing.T) {
+    tw := timing.New()
+    timer := tw.Loop(0, timing.NewLoopTask(10*time.Millisecond, 2, timing.TaskFN(func() {})))
+
+    time.Sleep(200 * time.Millisecond)
+    if !timer.Stopped() {
+        t.Errorf("Stopped() = false, want true")
+    }
+}
+
+func TestWheel_Repeat(t *testing.T) {
+    tw := timing.New()
+    var executed []time.Time
+    done := make(chan struct{})
+    delay := 10 * time.Millisecond
+    timer := tw.Repeat(0, timing.RepeatTaskFN(func() (time.Duration, bool) {
+        executed = append(executed, time.Now())
+        if len(executed) == 4 {
+            close(done)
+            return 0, false
+        }
+        delay *= 2
+        return delay, true
+    }))
+
+    select {
+    case <-done:
+    case <-time.After(time.Second):
+        t.Fatalf("Repeat() executed %d times, want 4", len(executed))
+    }
+    time.Sleep(10 * time.Millisecond)
+    if !timer.Stopped() {
+        t.Errorf("Stopped() = false, want true")
+    }
+    // 每次执行的间隔应随任务返回的延迟翻倍增长
+    for i, want := 1, 20*time.Millisecond; i < len(executed); i, want = i+1, want*2 {
+        if gap := executed[i].Sub(executed[i-1]); gap < want-5*time.Millisecond {
+            t.Errorf("gap %d = %v, want >= %v", i, gap, want)
+        }
+    }
+}
+
+func TestTimer_StopConcurrently(t *testing.T) {
+    tw := timing.New()
+    var executed atomic.Int64
+    var stopped atomic.Int64
+    var wg sync.WaitGroup
+
+    for i := 0; i < 200; i++ {
+        // 偶数计时器在停止前便已到期，用于覆盖停止与触发的并发场景
+        delay := time.Duration(i%2) * 200 * time.Millisecond
+        timer := tw.After(delay, timing.TaskFN(func() {
+            executed.Add(1)
+        }))
+        var stoppers sync.WaitGroup
+        for j := 0; j < 4; j++ {
+            stoppers.Add(1)
+            go func() {
+                defer stoppers.Done()
+                if timer.Stop() {
+                    stopped.Add(1)
+                }
+            }()
+        }
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            stoppers.Wait()
+            if !timer.Stopped() {
+                t.Errorf("Stopped() = false after Stop, want true")
+            }
+            if timer.Stop() {
+                t.Errorf("Stop() = true on a stopped timer, want false")
+            }
+        }()
+    }
+    wg.Wait()
+
+    if got := stopped.Load(); got != 200 {
+        t.Errorf("Stop() returned true %d times, want exactly once per timer (200)", got)
+    }
+    time.Sleep(300 * time.Millisecond)
+    if got := executed.Load(); got > 100 {
+        t.Errorf("executed %d timers, want <= 100 since delayed timers were stopped before firing", got)
+    }
+}
+
+// recordingExecutor 是一个同步执行任务并记录 panic 的执行器
+type recordingExecutor struct {
+    panics chan any
+}
+
+func (e *recordingExecutor) Execute(task func()) {
+    defer func() {
+        if err := recover(); err != nil {
+            e.panics <- err
+        }
+    }()
+    task()
+}
+
+func TestWheel_Name(t *testing.T) {
+    executor := &recordingExecutor{panics: make(chan any, 1)}
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithName("retry").WithExecutor(executor)
+    }))
+    if name := tw.Stats().Name; name != "retry" {
+        t.Errorf("Stats().Name = %q, want %q", name, "retry")
+    }
+
+    // 超出顶层时间轮区间的任务将由溢出轮执行，其名称应当继承自顶层时间轮
+    for _, delay := range []time.Duration{0, 100 * time.Millisecond} {
+        tw.After(delay, timing.TaskFN(func() {
+            panic("boom")
+        }))
+        select {
+        case err := <-executor.panics:
+            if message := fmt.Sprint(err); !strings.HasPrefix(message, "timing: wheel retry") || !strings.HasSuffix(message, "boom") {
+                t.Errorf("panic after %v = %q, want attributed to wheel retry", delay, message)
+            }
+        case <-time.After(time.Second):
+            t.Fatalf("task after %v did not panic", delay)
+        }
+    }
+
+    // 以 error 类型 panic 时，原始错误应当能够通过 errors.Is 取得
+    cause := errors.New("cause")
+    tw.After(0, timing.TaskFN(func() {
+        panic(cause)
+    }))
+    select {
+    case err := <-executor.panics:
+        if err, ok := err.(error); !ok || !errors.Is(err, cause) {
+            t.Errorf("panic = %v, want an error wrapping %v", err, cause)
+        }
+    case <-time.After(time.Second):
+        t.Fatalf("task did not panic")
+    }
+}
+
+func TestWheel_PauseResumeCron(t *testing.T) {
+    start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start)).WithLocation(time.UTC)
+    }))
+    var executed int
+    timer, err := tw.Cron("* * * * * * *", timing.TaskFN(func() {
+        executed++
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // 暂停期间错过的 5 次执行在恢复时仅补偿一次，此后从恢复时的时间继续调度
+    tw.Pause()
+    tw.Advance(5 * time.Second)
+    tw.Resume()
+    if executed != 1 {
+        t.Errorf("executed %d times on Resume, want 1", executed)
+    }
+    if expected := start.Add(6 * time.Second); !timer.ExpiresAt().Equal(expected) {
+        t.Errorf("ExpiresAt() after Resume = %v, want %v", timer.ExpiresAt(), expected)
+    }
+
+    tw.Advance(time.Second)
+    if executed != 2 {
+        t.Errorf("executed %d times one second after Resume, want 2", executed)
+    }
+}
+
+func TestWheel_PauseResume(t *testing.T) {
+    tw := timing.New()
+    var lock sync.Mutex
+    var executed []int
+    record := func(n int) timing.Task {
+        return timing.TaskFN(func() {
+            lock.Lock()
+            defer lock.Unlock()
+            executed = append(executed, n)
+        })
+    }
+
+    tw.Pause()
+    tw.After(30*time.Millisecond, record(3))
+    tw.After(0, record(0))
+    tw.After(10*time.Millisecond, record(1))
+    tw.After(20*time.Millisecond, record(2))
+    stopped := tw.After(25*time.Millisecond, record(-1))
+    tw.After(time.Hour, record(-2))
+
+    // 暂停跨越多个刻度，期间到期的计时器应被暂存，暂存期间被停止的计时器在恢复时不应执行
+    time.Sleep(100 * time.Millisecond)
+    stopped.Stop()
+    lock.Lock()
+    if len(executed) != 0 {
+        t.Errorf("executed %v while paused, want nothing", executed)
+    }
+    lock.Unlock()
+
+    tw.Resume()
+    time.Sleep(50 * time.Millisecond)
+    lock.Lock()
+    defer lock.Unlock()
+    if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(executed, want) {
+        t.Errorf("executed %v after Resume, want %v", executed, want)
+    }
+}
+
+func TestWheel_OverflowLevels(t *testing.T) {
+    if levels := len(timing.New().OverflowBucketSizes()); levels != 0 {
+        t.Fatalf("default len(OverflowBucketSizes()) = %d, want 0", levels)
+    }
+
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithOverflowLevels(2)
+    }))
+    if levels := len(tw.OverflowBucketSizes()); levels != 2 {
+        t.Fatalf("len(OverflowBucketSizes()) = %d, want 2 before any timer is added", levels)
+    }
+
+    // 默认配置下第 2 层溢出轮覆盖 8s，500ms 的计时器无需创建新的溢出轮
+    fired := make(chan struct{})
+    start := time.Now()
+    tw.After(500*time.Millisecond, timing.TaskFN(func() {
+        close(fired)
+    }))
+    if levels := len(tw.OverflowBucketSizes()); levels != 2 {
+        t.Errorf("len(OverflowBucketSizes()) = %d, want 2 after adding a timer", levels)
+    }
+
+    select {
+    case <-fired:
+        if elapsed := time.Since(start); elapsed < 490*time.Millisecond {
+            t.Errorf("timer fired after %v, want about 500ms", elapsed)
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatalf("timer in a preallocated overflow wheel did not fire")
+    }
+
+    // 超出预先创建的层数时仍将按需创建
+    tw.After(time.Minute, timing.TaskFN(func() {}))
+    if levels := len(tw.OverflowBucketSizes()); levels != 3 {
+        t.Errorf("len(OverflowBucketSizes()) = %d, want 3 after adding a far-future timer", levels)
+    }
+}
+
+func BenchmarkOverflowLevels(b *testing.B) {
+    for _, bm := range []struct {
+        name   string
+        levels int
+    }{
+        {"Lazy", 0},
+        {"Preallocated", 3},
+    } {
+        b.Run(bm.name, func(b *testing.B) {
+            task := timing.TaskFN(func() {})
+            latencies := make([]time.Duration, b.N)
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                b.StopTimer()
+                tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+                    config.WithOverflowLevels(bm.levels)
+                }))
+                b.StartTimer()
+
+                start := time.Now()
+                tw.After(time.Minute, task)
+                latencies[i] = time.Since(start)
+            }
+            b.StopTimer()
+
+            slices.Sort(latencies)
+            b.ReportMetric(float64(latencies[len(latencies)*99/100].Nanoseconds()), "p99-ns")
+        })
+    }
+}
+
+func TestWheel_QueueTrace(t *testing.T) {
+    var mu sync.Mutex
+    events := make(map[timing.QueueEventKind][]timing.QueueEvent)
+    tw := timing.New(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithQueueTrace(func(event timing.QueueEvent) {
+            mu.Lock()
+            defer mu.Unlock()
+            events[event.Kind] = append(events[event.Kind], event)
+        })
+    }))
+
+    fired := make(chan struct{})
+    start := time.Now()
+    tw.After(50*time.Millisecond, timing.TaskFN(func() {
+        close(fired)
+    }))
+    select {
+    case <-fired:
+    case <-time.After(time.Second):
+        t.Fatalf("timer did not fire with a queue trace")
+    }
+
+    mu.Lock()
+    defer mu.Unlock()
+    for _, kind := range []timing.QueueEventKind{
+        timing.QueueEventKindSleep,
+        timing.QueueEventKindWakeup,
+        timing.QueueEventKindProcess,
+        timing.QueueEventKindRefresh,
+    } {
+        if len(events[kind]) == 0 {
+            t.Fatalf("no events of kind %d, got %v", kind, events)
+        }
+    }
+
+    sleep := events[timing.QueueEventKindSleep][0]
+    if sleep.Delay <= 0 || sleep.Delay > 50*time.Millisecond {
+        t.Errorf("first sleep Delay = %v, want (0, 50ms]", sleep.Delay)
+    }
+    if sleep.Time.Before(start.Truncate(time.Millisecond)) || sleep.Time.After(time.Now()) {
+        t.Errorf("first sleep Time = %v, want between %v and now", sleep.Time, start)
+    }
+    for _, event := range events[timing.QueueEventKindProcess] {
+        if event.Delay < 0 {
+            t.Errorf("process event Delay = %v, want a non-negative lateness", event.Delay)
+        }
+    }
+}
+
+func TestWheel_LoopNow(t *testing.T) {
+    tw := timing.NewMockWheel()
+    var count int
+    timer := tw.LoopNow(timing.NewLoopTask(time.Second, 3, timing.TaskFN(func() {
+        count++
+    })))
+    if count != 1 {
+        t.Fatalf("LoopNow() executed %d times before returning, want 1", count)
+    }
+
+    tw.Advance(5 * time.Second)
+    if count != 3 || !timer.Stopped() {
+        t.Errorf("executed %d times, Stopped() = %v, want 3, true", count, timer.Stopped())
+    }
+
+    timer = tw.LoopNow(timing.NewLoopTask(time.Second, 1, timing.TaskFN(func() {})))
+    if !timer.Stopped() {
+        t.Errorf("Stopped() = false after the only iteration ran eagerly, want true")
+    }
+
+    defer func() {
+        if recover() == nil {
+            t.Errorf("LoopNow() did not propagate the panic of the eager run")
+        }
+    }()
+    tw.LoopNow(timing.NewLoopTask(time.Second, 1, timing.TaskFN(func() {
+        panic("eager")
+    })))
+}
+
+func TestWheel_EndOf(t *testing.T) {
+    start := time.Date(2023, 10, 1, 12, 0, 30, 0, time.UTC)
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(start))
+        config.WithLocation(time.UTC)
+    }))
+
+    var fired []time.Time
+    tw.LoopEndOf(chrono.UnitMinute, timing.TaskFN(func() {
+        fired = append(fired, tw.Now())
+    }))
+    var once []time.Time
+    tw.AtEndOf(chrono.UnitHour, timing.TaskFN(func() {
+        once = append(once, tw.Now())
+    }))
+    tw.Advance(3 * time.Minute)
+
+    want := []time.Time{
+        time.Date(2023, 10, 1, 12, 0, 59, 999000000, time.UTC),
+        time.Date(2023, 10, 1, 12, 1, 59, 999000000, time.UTC),
+        time.Date(2023, 10, 1, 12, 2, 59, 999000000, time.UTC),
+    }
+    if !reflect.DeepEqual(fired, want) {
+        t.Errorf("LoopEndOf(UnitMinute) fired at %v, want %v", fired, want)
+    }
+
+    tw.Advance(time.Hour)
+    if want := time.Date(2023, 10, 1, 12, 59, 59, 999000000, time.UTC); len(once) != 1 || !once[0].Equal(want) {
+        t.Errorf("AtEndOf(UnitHour) fired at %v, want [%v]", once, want)
+    }
+}
+
+func TestWheel_LoopSubTickInterval(t *testing.T) {
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)))
+        config.WithTick(10 * time.Millisecond)
+    }))
+
+    var fired []time.Time
+    task := timing.NewForeverLoopTask(100*time.Microsecond, timing.TaskFN(func() {
+        fired = append(fired, tw.Now())
+    }))
+    tw.Loop(0, task)
+    tw.Advance(100 * time.Millisecond)
+
+    if len(fired) != 11 {
+        t.Fatalf("sub-tick loop fired %d times in 100ms, want 11", len(fired))
+    }
+    for i := 1; i < len(fired); i++ {
+        if interval := fired[i].Sub(fired[i-1]); interval != 10*time.Millisecond {
+            t.Errorf("interval between executions %d and %d = %v, want the 10ms tick", i-1, i, interval)
+        }
+    }
+}
+
+func TestWheel_Chain(t *testing.T) {
+    tw := timing.NewMockWheel(timing.ConfiguratorFN(func(config timing.Configuration) {
+        config.WithClock(timing.NewManualClock(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)))
+    }))
+    start := tw.Now()
+    type step struct {
+        name string
+        at   time.Duration
+    }
+    var executed []step
+    record := func(name string) timing.Task {
+        return timing.TaskFN(func() {
+            executed = append(executed, step{name, tw.Now().Sub(start)})
+        })
+    }
+
+    timer := tw.Chain(record("a"),
+        timing.ChainStep{Delay: time.Second, Task: record("b")},
+        timing.ChainStep{Delay: 2 * time.Second, Task: record("c")},
+    )
+    tw.Advance(10 * time.Second)
+
+    if want := []step{{"a", 0}, {"b", time.Second}, {"c", 3 * time.Second}}; !reflect.DeepEqual(executed, want) {
+        t.Errorf("executed %v, want %v", executed, want)
+    }
+    if !timer.Stopped() {
+        t.Errorf("Stopped() after the chain completed = false, want true")
+    }
+
+    // 步骤发生 panic 时后续步骤不再执行
+    executed = nil
+    tw.Chain(timing.TaskFN(func() {
+        panic("step failed")
+    }), timing.ChainStep{Delay: time.Second, Task: record("after panic")})
+    tw.Advance(10 * time.Second)
+    if len(executed) != 0 {
+        t.Errorf("executed %v after a step panicked, want nothing", executed)
+    }
+
+    // 停止后尚未执行的步骤不再执行
+    executed = nil
+    stopped := tw.Chain(record("first"), timing.ChainStep{Delay: time.Second, Task: record("second")})
+    tw.Advance(0)
+    stopped.Stop()
+    tw.Advance(10 * time.Second)
+    if want := []step{{"first", 20 * time.Second}}; !reflect.DeepEqual(executed, want) {
+        t.Errorf("executed %v after Stop, want %v", executed, want)
+    }
+}